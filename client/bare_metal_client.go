@@ -7,7 +7,7 @@ import "github.com/MustWin/baremetal-sdk-go"
 type BareMetalClient interface {
 	AddUserToGroup(userID, groupID string, opts *baremetal.RetryTokenOptions) (res *baremetal.UserGroupMembership, e error)
 
-	AttachVolume(attachmentType, instanceID, volumeID string, opts *baremetal.CreateOptions) (res *baremetal.VolumeAttachment, e error)
+	AttachVolume(attachmentType, instanceID, volumeID string, opts *baremetal.AttachVolumeOptions) (res *baremetal.VolumeAttachment, e error)
 
 	CaptureConsoleHistory(instanceID string, opts *baremetal.RetryTokenOptions) (icHistory *baremetal.ConsoleHistoryMetadata, e error)
 
@@ -17,7 +17,9 @@ type BareMetalClient interface {
 	CreateCertificate(loadBalancerID string, certificateName string, caCertificate string, privateKey string, passphrase string, publicCertificate string, opts *baremetal.LoadBalancerOptions) (workRequestID string, e error)
 	CreateCompartment(name, desc string, opts *baremetal.RetryTokenOptions) (res *baremetal.Compartment, e error)
 	CreateCpe(compartmentID, ipAddress string, opts *baremetal.CreateOptions) (cpe *baremetal.Cpe, e error)
+	CreateDBHome(dbSystemID string, dbHome baremetal.CreateDBHomeDetails, opts *baremetal.RetryTokenOptions) (res *baremetal.DBHome, e error)
 	CreateDHCPOptions(compartmentID, vcnID string, dhcpOptions []baremetal.DHCPDNSOption, opts *baremetal.CreateOptions) (res *baremetal.DHCPOptions, e error)
+	CreateDatabase(dbHomeID string, database baremetal.CreateDatabaseDetails, opts *baremetal.RetryTokenOptions) (res *baremetal.Database, e error)
 	CreateDrg(compartmentID string, opts *baremetal.CreateOptions) (res *baremetal.Drg, e error)
 	CreateDrgAttachment(drgID, vcnID string, opts *baremetal.CreateOptions) (res *baremetal.DrgAttachment, e error)
 	CreateGroup(name, desc string, opts *baremetal.RetryTokenOptions) (res *baremetal.Group, e error)
@@ -25,7 +27,7 @@ type BareMetalClient interface {
 	CreateImage(compartmentID, instanceID string, opts *baremetal.CreateOptions) (res *baremetal.Image, e error)
 	CreateInternetGateway(compartmentID, vcnID string, isEnabled bool, opts *baremetal.CreateOptions) (gw *baremetal.InternetGateway, e error)
 	CreateListener(loadBalancerID string, name string, defaultBackendSetName string, protocol string, port int, sslConfig *baremetal.SSLConfiguration, opts *baremetal.LoadBalancerOptions) (workRequestID string, e error)
-	CreateLoadBalancer(backendSets *baremetal.BackendSet, certificates *baremetal.Certificate, compartmentID string, listeners *baremetal.Listener, shape string, subnetIDs []string, opts *baremetal.CreateOptions) (workRequestID string, e error)
+	CreateLoadBalancer(backendSets map[string]baremetal.BackendSet, certificates map[string]baremetal.Certificate, compartmentID string, listeners map[string]baremetal.Listener, shape string, subnetIDs []string, opts *baremetal.CreateOptions) (workRequestID string, e error)
 	CreateOrResetUIPassword(userID string, opts *baremetal.RetryTokenOptions) (resource *baremetal.UIPassword, e error)
 	CreatePolicy(name, desc, compartmentID string, statements []string, opts *baremetal.CreatePolicyOptions) (res *baremetal.Policy, e error)
 	CreateRouteTable(compartmentID, vcnID string, routeRules []baremetal.RouteRule, opts *baremetal.CreateOptions) (res *baremetal.RouteTable, e error)
@@ -37,13 +39,16 @@ type BareMetalClient interface {
 	CreateVolume(availabilityDomain, compartmentID string, opts *baremetal.CreateVolumeOptions) (res *baremetal.Volume, e error)
 	CreateVolumeBackup(volumeID string, opts *baremetal.CreateOptions) (vol *baremetal.VolumeBackup, e error)
 
+	DBNodeAction(id string, action baremetal.DBNodeAction, opts *baremetal.HeaderOptions) (inst *baremetal.DBNode, e error)
 	DeleteAPIKey(userID, fingerprint string, opts *baremetal.IfMatchOptions) (e error)
 	DeleteBackend(loadBalancerID string, backendSetName string, backendName string, opts *baremetal.ClientRequestOptions) (workRequestID string, e error)
 	DeleteBackendSet(loadBalancerID string, backendSetName string, opts *baremetal.ClientRequestOptions) (workRequestID string, e error)
 	DeleteBucket(name string, namespaceName baremetal.Namespace, opts *baremetal.IfMatchOptions) (e error)
 	DeleteCertificate(loadBalancerID string, certificateName string, opts *baremetal.ClientRequestOptions) (workRequestID string, e error)
 	DeleteCpe(id string, opts *baremetal.IfMatchOptions) (e error)
+	DeleteDBHome(id string, opts *baremetal.IfMatchOptions) (e error)
 	DeleteDHCPOptions(id string, opts *baremetal.IfMatchOptions) (e error)
+	DeleteDatabase(id string, opts *baremetal.IfMatchOptions) (e error)
 	DeleteDrg(id string, opts *baremetal.IfMatchOptions) (e error)
 	DeleteDrgAttachment(id string, opts *baremetal.IfMatchOptions) (e error)
 	DeleteGroup(id string, opts *baremetal.IfMatchOptions) (e error)
@@ -167,6 +172,7 @@ type BareMetalClient interface {
 	UpdateBucket(compartmentID string, name string, namespaceName baremetal.Namespace, opts *baremetal.UpdateBucketOptions) (bckt *baremetal.Bucket, e error)
 	UpdateCompartment(id string, opts *baremetal.UpdateIdentityOptions) (res *baremetal.Compartment, e error)
 	UpdateCpe(id string, opts *baremetal.IfMatchDisplayNameOptions) (cpe *baremetal.Cpe, e error)
+	UpdateDBSystem(id string, opts *baremetal.UpdateDBSystemOptions) (res *baremetal.DBSystem, e error)
 	UpdateDHCPOptions(id string, opts *baremetal.UpdateDHCPDNSOptions) (res *baremetal.DHCPOptions, e error)
 	UpdateDrg(id string, opts *baremetal.IfMatchDisplayNameOptions) (drg *baremetal.Drg, e error)
 	UpdateDrgAttachment(id string, opts *baremetal.IfMatchDisplayNameOptions) (drg *baremetal.DrgAttachment, e error)
@@ -185,7 +191,7 @@ type BareMetalClient interface {
 	UpdateSwiftPassword(id, userID string, opts *baremetal.UpdateIdentityOptions) (res *baremetal.SwiftPassword, e error)
 	UpdateUser(id string, opts *baremetal.UpdateIdentityOptions) (res *baremetal.User, e error)
 	UpdateVirtualNetwork(id string, opts *baremetal.IfMatchDisplayNameOptions) (vcn *baremetal.VirtualNetwork, e error)
-	UpdateVolume(id string, opts *baremetal.UpdateOptions) (res *baremetal.Volume, e error)
+	UpdateVolume(id string, opts *baremetal.UpdateVolumeOptions) (res *baremetal.Volume, e error)
 	UpdateVolumeBackup(id string, opts *baremetal.IfMatchDisplayNameOptions) (vol *baremetal.VolumeBackup, e error)
 
 	UploadAPIKey(userID, key string, opts *baremetal.RetryTokenOptions) (apiKey *baremetal.APIKey, e error)
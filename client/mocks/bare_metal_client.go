@@ -33,11 +33,11 @@ func (_m *BareMetalClient) AddUserToGroup(userID string, groupID string, opts *b
 }
 
 // AttachVolume provides a mock function with given fields: attachmentType, instanceID, volumeID, opts
-func (_m *BareMetalClient) AttachVolume(attachmentType string, instanceID string, volumeID string, opts *baremetal.CreateOptions) (*baremetal.VolumeAttachment, error) {
+func (_m *BareMetalClient) AttachVolume(attachmentType string, instanceID string, volumeID string, opts *baremetal.AttachVolumeOptions) (*baremetal.VolumeAttachment, error) {
 	ret := _m.Called(attachmentType, instanceID, volumeID, opts)
 
 	var r0 *baremetal.VolumeAttachment
-	if rf, ok := ret.Get(0).(func(string, string, string, *baremetal.CreateOptions) *baremetal.VolumeAttachment); ok {
+	if rf, ok := ret.Get(0).(func(string, string, string, *baremetal.AttachVolumeOptions) *baremetal.VolumeAttachment); ok {
 		r0 = rf(attachmentType, instanceID, volumeID, opts)
 	} else {
 		if ret.Get(0) != nil {
@@ -46,7 +46,7 @@ func (_m *BareMetalClient) AttachVolume(attachmentType string, instanceID string
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, *baremetal.CreateOptions) error); ok {
+	if rf, ok := ret.Get(1).(func(string, string, string, *baremetal.AttachVolumeOptions) error); ok {
 		r1 = rf(attachmentType, instanceID, volumeID, opts)
 	} else {
 		r1 = ret.Error(1)
@@ -210,6 +210,29 @@ func (_m *BareMetalClient) CreateCpe(compartmentID string, ipAddress string, opt
 	return r0, r1
 }
 
+// CreateDBHome provides a mock function with given fields: dbSystemID, dbHome, opts
+func (_m *BareMetalClient) CreateDBHome(dbSystemID string, dbHome baremetal.CreateDBHomeDetails, opts *baremetal.RetryTokenOptions) (*baremetal.DBHome, error) {
+	ret := _m.Called(dbSystemID, dbHome, opts)
+
+	var r0 *baremetal.DBHome
+	if rf, ok := ret.Get(0).(func(string, baremetal.CreateDBHomeDetails, *baremetal.RetryTokenOptions) *baremetal.DBHome); ok {
+		r0 = rf(dbSystemID, dbHome, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*baremetal.DBHome)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, baremetal.CreateDBHomeDetails, *baremetal.RetryTokenOptions) error); ok {
+		r1 = rf(dbSystemID, dbHome, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDHCPOptions provides a mock function with given fields: compartmentID, vcnID, dhcpOptions, opts
 func (_m *BareMetalClient) CreateDHCPOptions(compartmentID string, vcnID string, dhcpOptions []baremetal.DHCPDNSOption, opts *baremetal.CreateOptions) (*baremetal.DHCPOptions, error) {
 	ret := _m.Called(compartmentID, vcnID, dhcpOptions, opts)
@@ -233,6 +256,29 @@ func (_m *BareMetalClient) CreateDHCPOptions(compartmentID string, vcnID string,
 	return r0, r1
 }
 
+// CreateDatabase provides a mock function with given fields: dbHomeID, database, opts
+func (_m *BareMetalClient) CreateDatabase(dbHomeID string, database baremetal.CreateDatabaseDetails, opts *baremetal.RetryTokenOptions) (*baremetal.Database, error) {
+	ret := _m.Called(dbHomeID, database, opts)
+
+	var r0 *baremetal.Database
+	if rf, ok := ret.Get(0).(func(string, baremetal.CreateDatabaseDetails, *baremetal.RetryTokenOptions) *baremetal.Database); ok {
+		r0 = rf(dbHomeID, database, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*baremetal.Database)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, baremetal.CreateDatabaseDetails, *baremetal.RetryTokenOptions) error); ok {
+		r1 = rf(dbHomeID, database, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDrg provides a mock function with given fields: compartmentID, opts
 func (_m *BareMetalClient) CreateDrg(compartmentID string, opts *baremetal.CreateOptions) (*baremetal.Drg, error) {
 	ret := _m.Called(compartmentID, opts)
@@ -393,18 +439,18 @@ func (_m *BareMetalClient) CreateListener(loadBalancerID string, name string, de
 }
 
 // CreateLoadBalancer provides a mock function with given fields: backendSets, certificates, compartmentID, listeners, shape, subnetIDs, opts
-func (_m *BareMetalClient) CreateLoadBalancer(backendSets *baremetal.BackendSet, certificates *baremetal.Certificate, compartmentID string, listeners *baremetal.Listener, shape string, subnetIDs []string, opts *baremetal.CreateOptions) (string, error) {
+func (_m *BareMetalClient) CreateLoadBalancer(backendSets map[string]baremetal.BackendSet, certificates map[string]baremetal.Certificate, compartmentID string, listeners map[string]baremetal.Listener, shape string, subnetIDs []string, opts *baremetal.CreateOptions) (string, error) {
 	ret := _m.Called(backendSets, certificates, compartmentID, listeners, shape, subnetIDs, opts)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(*baremetal.BackendSet, *baremetal.Certificate, string, *baremetal.Listener, string, []string, *baremetal.CreateOptions) string); ok {
+	if rf, ok := ret.Get(0).(func(map[string]baremetal.BackendSet, map[string]baremetal.Certificate, string, map[string]baremetal.Listener, string, []string, *baremetal.CreateOptions) string); ok {
 		r0 = rf(backendSets, certificates, compartmentID, listeners, shape, subnetIDs, opts)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*baremetal.BackendSet, *baremetal.Certificate, string, *baremetal.Listener, string, []string, *baremetal.CreateOptions) error); ok {
+	if rf, ok := ret.Get(1).(func(map[string]baremetal.BackendSet, map[string]baremetal.Certificate, string, map[string]baremetal.Listener, string, []string, *baremetal.CreateOptions) error); ok {
 		r1 = rf(backendSets, certificates, compartmentID, listeners, shape, subnetIDs, opts)
 	} else {
 		r1 = ret.Error(1)
@@ -643,6 +689,29 @@ func (_m *BareMetalClient) CreateVolumeBackup(volumeID string, opts *baremetal.C
 	return r0, r1
 }
 
+// DBNodeAction provides a mock function with given fields: id, action, opts
+func (_m *BareMetalClient) DBNodeAction(id string, action baremetal.DBNodeAction, opts *baremetal.HeaderOptions) (*baremetal.DBNode, error) {
+	ret := _m.Called(id, action, opts)
+
+	var r0 *baremetal.DBNode
+	if rf, ok := ret.Get(0).(func(string, baremetal.DBNodeAction, *baremetal.HeaderOptions) *baremetal.DBNode); ok {
+		r0 = rf(id, action, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*baremetal.DBNode)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, baremetal.DBNodeAction, *baremetal.HeaderOptions) error); ok {
+		r1 = rf(id, action, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAPIKey provides a mock function with given fields: userID, fingerprint, opts
 func (_m *BareMetalClient) DeleteAPIKey(userID string, fingerprint string, opts *baremetal.IfMatchOptions) error {
 	ret := _m.Called(userID, fingerprint, opts)
@@ -748,6 +817,20 @@ func (_m *BareMetalClient) DeleteCpe(id string, opts *baremetal.IfMatchOptions)
 	return r0
 }
 
+// DeleteDBHome provides a mock function with given fields: id, opts
+func (_m *BareMetalClient) DeleteDBHome(id string, opts *baremetal.IfMatchOptions) error {
+	ret := _m.Called(id, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *baremetal.IfMatchOptions) error); ok {
+		r0 = rf(id, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDHCPOptions provides a mock function with given fields: id, opts
 func (_m *BareMetalClient) DeleteDHCPOptions(id string, opts *baremetal.IfMatchOptions) error {
 	ret := _m.Called(id, opts)
@@ -762,6 +845,20 @@ func (_m *BareMetalClient) DeleteDHCPOptions(id string, opts *baremetal.IfMatchO
 	return r0
 }
 
+// DeleteDatabase provides a mock function with given fields: id, opts
+func (_m *BareMetalClient) DeleteDatabase(id string, opts *baremetal.IfMatchOptions) error {
+	ret := _m.Called(id, opts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *baremetal.IfMatchOptions) error); ok {
+		r0 = rf(id, opts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDrg provides a mock function with given fields: id, opts
 func (_m *BareMetalClient) DeleteDrg(id string, opts *baremetal.IfMatchOptions) error {
 	ret := _m.Called(id, opts)
@@ -3182,6 +3279,29 @@ func (_m *BareMetalClient) UpdateCpe(id string, opts *baremetal.IfMatchDisplayNa
 	return r0, r1
 }
 
+// UpdateDBSystem provides a mock function with given fields: id, opts
+func (_m *BareMetalClient) UpdateDBSystem(id string, opts *baremetal.UpdateDBSystemOptions) (*baremetal.DBSystem, error) {
+	ret := _m.Called(id, opts)
+
+	var r0 *baremetal.DBSystem
+	if rf, ok := ret.Get(0).(func(string, *baremetal.UpdateDBSystemOptions) *baremetal.DBSystem); ok {
+		r0 = rf(id, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*baremetal.DBSystem)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *baremetal.UpdateDBSystemOptions) error); ok {
+		r1 = rf(id, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDHCPOptions provides a mock function with given fields: id, opts
 func (_m *BareMetalClient) UpdateDHCPOptions(id string, opts *baremetal.UpdateDHCPDNSOptions) (*baremetal.DHCPOptions, error) {
 	ret := _m.Called(id, opts)
@@ -3591,11 +3711,11 @@ func (_m *BareMetalClient) UpdateVirtualNetwork(id string, opts *baremetal.IfMat
 }
 
 // UpdateVolume provides a mock function with given fields: id, opts
-func (_m *BareMetalClient) UpdateVolume(id string, opts *baremetal.UpdateOptions) (*baremetal.Volume, error) {
+func (_m *BareMetalClient) UpdateVolume(id string, opts *baremetal.UpdateVolumeOptions) (*baremetal.Volume, error) {
 	ret := _m.Called(id, opts)
 
 	var r0 *baremetal.Volume
-	if rf, ok := ret.Get(0).(func(string, *baremetal.UpdateOptions) *baremetal.Volume); ok {
+	if rf, ok := ret.Get(0).(func(string, *baremetal.UpdateVolumeOptions) *baremetal.Volume); ok {
 		r0 = rf(id, opts)
 	} else {
 		if ret.Get(0) != nil {
@@ -3604,7 +3724,7 @@ func (_m *BareMetalClient) UpdateVolume(id string, opts *baremetal.UpdateOptions
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, *baremetal.UpdateOptions) error); ok {
+	if rf, ok := ret.Get(1).(func(string, *baremetal.UpdateVolumeOptions) error); ok {
 		r1 = rf(id, opts)
 	} else {
 		r1 = ret.Error(1)
@@ -0,0 +1,96 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-ini/ini"
+	"github.com/mitchellh/go-homedir"
+)
+
+const defaultConfigFileProfile = "DEFAULT"
+
+// configFileKeys maps the keys of the SDK and CLI config file to the
+// provider attributes they supply.
+var configFileKeys = map[string]string{
+	"tenancy_ocid":         "tenancy",
+	"user_ocid":            "user",
+	"fingerprint":          "fingerprint",
+	"private_key_path":     "key_file",
+	"private_key_password": "pass_phrase",
+	"region":               "region",
+}
+
+func defaultConfigFilePath() string {
+	home, err := homedir.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".oraclebmc", "config")
+}
+
+// readConfigFile returns the provider attributes set by profile in the config
+// file at path. Profiles inherit values from the DEFAULT profile. A missing
+// file is only an error when its path was given explicitly.
+func readConfigFile(path, profile string) (values map[string]string, err error) {
+	values = map[string]string{}
+
+	explicitPath := path != ""
+	if !explicitPath {
+		if path = defaultConfigFilePath(); path == "" {
+			return
+		}
+	}
+	if path, err = homedir.Expand(path); err != nil {
+		return
+	}
+
+	if _, err = os.Stat(path); err != nil {
+		if os.IsNotExist(err) && !explicitPath {
+			err = nil
+		}
+		return
+	}
+
+	var f *ini.File
+	if f, err = ini.Load(path); err != nil {
+		err = fmt.Errorf("Could not read config file %s: %s", path, err)
+		return
+	}
+
+	if profile == "" {
+		profile = defaultConfigFileProfile
+	}
+
+	sections := []*ini.Section{}
+	if section, e := f.GetSection(profile); e == nil {
+		sections = append(sections, section)
+	} else if profile != defaultConfigFileProfile {
+		err = fmt.Errorf("Profile %s not found in config file %s", profile, path)
+		return
+	}
+	if profile != defaultConfigFileProfile {
+		if section, e := f.GetSection(defaultConfigFileProfile); e == nil {
+			sections = append(sections, section)
+		}
+	}
+
+	for attr, key := range configFileKeys {
+		for _, section := range sections {
+			if section.HasKey(key) {
+				values[attr] = section.Key(key).String()
+				break
+			}
+		}
+	}
+
+	if keyFile, ok := values["private_key_path"]; ok {
+		if values["private_key_path"], err = homedir.Expand(keyFile); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/suite"
+)
+
+const testConfigFile = `
+[DEFAULT]
+user=ocid1.user.oc1..defaultuser
+fingerprint=b4:8a:7d:54:e6:81:04:b2:fa:ce:ba:55:34:dd:00:00
+key_file=~/keys/default.pem
+tenancy=ocid1.tenancy.oc1..defaulttenancy
+region=us-phoenix-1
+
+[ASHBURN]
+user=ocid1.user.oc1..ashburnuser
+key_file=KEY_FILE
+pass_phrase=password
+region=us-ashburn-1
+`
+
+type ConfigFileTestSuite struct {
+	suite.Suite
+	Home    string
+	Path    string
+	KeyPath string
+	oldHome string
+}
+
+func (s *ConfigFileTestSuite) SetupTest() {
+	var err error
+	s.Home, err = ioutil.TempDir("", "config_file")
+	s.Require().NoError(err)
+
+	s.oldHome = os.Getenv("HOME")
+	os.Setenv("HOME", s.Home)
+	homedir.DisableCache = true
+
+	s.KeyPath = filepath.Join(s.Home, "key.pem")
+	s.Require().NoError(ioutil.WriteFile(s.KeyPath, []byte(testPrivateKey), 0600))
+
+	s.Path = filepath.Join(s.Home, "config")
+	config := strings.Replace(testConfigFile, "KEY_FILE", s.KeyPath, 1)
+	s.Require().NoError(ioutil.WriteFile(s.Path, []byte(config), 0600))
+}
+
+func (s *ConfigFileTestSuite) TearDownTest() {
+	os.Setenv("HOME", s.oldHome)
+	homedir.DisableCache = false
+	os.RemoveAll(s.Home)
+}
+
+func (s *ConfigFileTestSuite) TestDefaultProfile() {
+	values, err := readConfigFile(s.Path, "")
+	s.Require().NoError(err)
+	s.Equal("ocid1.tenancy.oc1..defaulttenancy", values["tenancy_ocid"])
+	s.Equal("ocid1.user.oc1..defaultuser", values["user_ocid"])
+	s.Equal(filepath.Join(s.Home, "keys", "default.pem"), values["private_key_path"])
+	s.Equal("us-phoenix-1", values["region"])
+	_, ok := values["private_key_password"]
+	s.False(ok)
+}
+
+func (s *ConfigFileTestSuite) TestNamedProfileInheritsDefault() {
+	values, err := readConfigFile(s.Path, "ASHBURN")
+	s.Require().NoError(err)
+	s.Equal("ocid1.user.oc1..ashburnuser", values["user_ocid"])
+	s.Equal("ocid1.tenancy.oc1..defaulttenancy", values["tenancy_ocid"])
+	s.Equal(s.KeyPath, values["private_key_path"])
+	s.Equal("password", values["private_key_password"])
+	s.Equal("us-ashburn-1", values["region"])
+}
+
+func (s *ConfigFileTestSuite) TestMissingProfile() {
+	_, err := readConfigFile(s.Path, "LONDON")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "Profile LONDON not found")
+}
+
+func (s *ConfigFileTestSuite) TestMissingFile() {
+	_, err := readConfigFile(filepath.Join(s.Home, "missing"), "")
+	s.Error(err)
+
+	// The default location is optional.
+	values, err := readConfigFile("", "")
+	s.NoError(err)
+	s.Empty(values)
+}
+
+func (s *ConfigFileTestSuite) TestDefaultLocation() {
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.Home, ".oraclebmc"), 0700))
+	s.Require().NoError(os.Rename(s.Path, filepath.Join(s.Home, ".oraclebmc", "config")))
+
+	values, err := readConfigFile("", "")
+	s.Require().NoError(err)
+	s.Equal("ocid1.user.oc1..defaultuser", values["user_ocid"])
+}
+
+func (s *ConfigFileTestSuite) TestProviderConfig() {
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	d.Set("config_file_path", s.Path)
+	d.Set("config_file_profile", "ASHBURN")
+	d.Set("fingerprint", testKeyFingerPrint)
+
+	client, err := providerConfig(d)
+	s.NoError(err)
+	s.NotNil(client)
+
+	// The explicit key overrides the file's key_file, which does not exist
+	// for the DEFAULT profile.
+	d = (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	d.Set("config_file_path", s.Path)
+	d.Set("private_key", testPrivateKey)
+	d.Set("private_key_password", "password")
+	client, err = providerConfig(d)
+	s.NoError(err)
+	s.NotNil(client)
+}
+
+func (s *ConfigFileTestSuite) TestProviderConfigMissingSetting() {
+	s.Require().NoError(ioutil.WriteFile(s.Path, []byte("[DEFAULT]\nuser=ocid1.user.oc1..user\n"), 0600))
+
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	d.Set("config_file_path", s.Path)
+	d.Set("fingerprint", testKeyFingerPrint)
+	d.Set("private_key", testPrivateKey)
+
+	_, err := providerConfig(d)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "tenancy_ocid is required")
+}
+
+func TestConfigFileTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigFileTestSuite))
+}
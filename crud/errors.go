@@ -0,0 +1,107 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/MustWin/baremetal-sdk-go"
+)
+
+// NotFoundRule matches API errors that mean a resource no longer exists. An
+// empty Code matches any service code returned with Status.
+type NotFoundRule struct {
+	Status int
+	Code   string
+}
+
+func (r NotFoundRule) matches(e *baremetal.Error) bool {
+	return StatusOf(e) == r.Status && (r.Code == "" || r.Code == e.Code)
+}
+
+// StatusOf returns the HTTP status of an API error, or 0 for other errors.
+func StatusOf(err error) int {
+	if e, ok := err.(*baremetal.Error); ok {
+		status, _ := strconv.Atoi(e.Status)
+		return status
+	}
+	return 0
+}
+
+// IsPreconditionFailed reports whether err is the API refusing an update or
+// delete because its If-Match ETag is out of date.
+func IsPreconditionFailed(err error) bool {
+	return StatusOf(err) == http.StatusPreconditionFailed
+}
+
+// DefaultNotFoundRules apply to resources that don't have their own. Every
+// service answers 404 for missing resources, with codes such as
+// NotAuthorizedOrNotFound, BucketNotFound and NotFound.
+var DefaultNotFoundRules = []NotFoundRule{
+	{Status: 404},
+}
+
+// NotFoundClassifier is implemented by resources whose services report
+// missing resources other than with DefaultNotFoundRules.
+type NotFoundClassifier interface {
+	NotFoundRules() []NotFoundRule
+}
+
+// NotFoundError is returned by resources that look themselves up in a list,
+// when they are not in it.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// NewNotFoundError formats a NotFoundError.
+func NewNotFoundError(format string, a ...interface{}) error {
+	return &NotFoundError{Message: fmt.Sprintf(format, a...)}
+}
+
+// IsNotFound reports whether err means the resource managed by sync is gone.
+func IsNotFound(sync interface{}, err error) bool {
+	switch e := err.(type) {
+	case *NotFoundError:
+		return true
+	case *baremetal.Error:
+		rules := DefaultNotFoundRules
+		if classifier, ok := sync.(NotFoundClassifier); ok {
+			rules = classifier.NotFoundRules()
+		}
+		for _, rule := range rules {
+			if rule.matches(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// handleMissingResourceError voids the resource and clears err when err
+// means the resource is gone.
+func handleMissingResourceError(sync ResourceVoider, err *error) {
+	if err == nil || *err == nil || !IsNotFound(sync, *err) {
+		return
+	}
+
+	if e, ok := (*err).(*baremetal.Error); ok {
+		log.Printf("[DEBUG] Object does not exist (status %s, code %s, opc-request-id %s), voiding resource and nullifying error", e.Status, e.Code, e.OPCRequestID)
+	} else {
+		log.Printf("[DEBUG] Object does not exist (%s), voiding resource and nullifying error", *err)
+	}
+	sync.VoidState()
+	*err = nil
+}
+
+// FilterMissingResourceError voids the resource and clears err when err
+// means the resource is gone.
+func FilterMissingResourceError(sync ResourceVoider, err *error) {
+	handleMissingResourceError(sync, err)
+}
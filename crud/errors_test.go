@@ -0,0 +1,94 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type voider struct {
+	voided bool
+}
+
+func (v *voider) VoidState() {
+	v.voided = true
+}
+
+// conflictVoider also treats a conflict with a deleted parent as missing.
+type conflictVoider struct {
+	voider
+}
+
+func (v *conflictVoider) NotFoundRules() []NotFoundRule {
+	return []NotFoundRule{{Status: 404}, {Status: 409, Code: "ParentDeleted"}}
+}
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func (s *ErrorsTestSuite) TestMissingResource() {
+	for _, err := range []error{
+		&baremetal.Error{Status: "404", Code: "NotAuthorizedOrNotFound", OPCRequestID: "request"},
+		&baremetal.Error{Status: "404", Code: "BucketNotFound"},
+		NewNotFoundError("Listener %s does not exist", "http"),
+	} {
+		v := &voider{}
+		e := err
+		handleMissingResourceError(v, &e)
+		s.NoError(e)
+		s.True(v.voided, err.Error())
+	}
+}
+
+func (s *ErrorsTestSuite) TestOtherErrorsAreKept() {
+	for _, err := range []error{
+		// Messages are not parsed.
+		&baremetal.Error{Status: "400", Code: "InvalidParameter", Message: "Subnet not found in VCN"},
+		errors.New("Load balancer lb has no listener named http"),
+		&baremetal.Error{Status: "409", Code: "ParentDeleted"},
+	} {
+		v := &voider{}
+		e := err
+		handleMissingResourceError(v, &e)
+		s.Equal(err, e)
+		s.False(v.voided, err.Error())
+	}
+}
+
+func (s *ErrorsTestSuite) TestResourceRules() {
+	v := &conflictVoider{}
+	var e error = &baremetal.Error{Status: "409", Code: "ParentDeleted"}
+	handleMissingResourceError(v, &e)
+	s.NoError(e)
+	s.True(v.voided)
+
+	s.False(IsNotFound(v, &baremetal.Error{Status: "409", Code: "IncorrectState"}))
+	s.True(IsNotFound(v, &baremetal.Error{Status: "404", Code: "NotFound"}))
+}
+
+func (s *ErrorsTestSuite) TestStatus() {
+	s.Equal(412, StatusOf(&baremetal.Error{Status: "412", Code: "NoEtagMatch"}))
+	s.Equal(0, StatusOf(errors.New("412")))
+	s.Equal(0, StatusOf(nil))
+
+	s.True(IsPreconditionFailed(&baremetal.Error{Status: "412"}))
+	s.False(IsPreconditionFailed(&baremetal.Error{Status: "409"}))
+	s.False(IsPreconditionFailed(nil))
+}
+
+func (s *ErrorsTestSuite) TestNilError() {
+	v := &voider{}
+	var e error
+	handleMissingResourceError(v, &e)
+	handleMissingResourceError(v, nil)
+	s.False(v.voided)
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ETagSchema holds the ETag of the resource as last read. Resources whose
+// API returns ETags include it as "etag".
+var ETagSchema = &schema.Schema{
+	Type:     schema.TypeString,
+	Computed: true,
+}
+
+// IfMatch returns the ETag to send as If-Match, or "" when the provider's
+// use_etags setting is off.
+func (s *BaseCrud) IfMatch() string {
+	if !SettingsOf(s.Client).UseETags {
+		return ""
+	}
+	etag, _ := s.D.Get("etag").(string)
+	return etag
+}
+
+// IfMatchOptions returns If-Match options for deletes, or nil when there is
+// no ETag to send.
+func (s *BaseCrud) IfMatchOptions() *baremetal.IfMatchOptions {
+	if etag := s.IfMatch(); etag != "" {
+		return &baremetal.IfMatchOptions{IfMatch: etag}
+	}
+	return nil
+}
+
+type eTagSetter interface {
+	setETag(sync interface{}) error
+}
+
+// setETag records the ETag of sync's .Res or .Resource, for resources that
+// have an etag attribute.
+func (s *BaseCrud) setETag(sync interface{}) error {
+	if s.D.Get("etag") == nil {
+		return nil
+	}
+	v := reflect.ValueOf(sync).Elem()
+	for _, key := range []string{"Res", "Resource"} {
+		if ref := v.FieldByName(key); ref.IsValid() && ref.Kind() == reflect.Ptr && !ref.IsNil() {
+			if etag := ref.Elem().FieldByName("ETag"); etag.IsValid() && etag.Kind() == reflect.String && etag.String() != "" {
+				if err := s.D.Set("etag", etag.String()); err != nil {
+					return fmt.Errorf("Could not record the ETag of %s: %s", s.D.Id(), err)
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func setETag(sync interface{}) error {
+	if setter, ok := sync.(eTagSetter); ok {
+		return setter.setETag(sync)
+	}
+	return nil
+}
+
+// checkPrecondition explains a failed If-Match: the resource was changed by
+// someone else after it was refreshed.
+func checkPrecondition(d *schema.ResourceData, err error) error {
+	if IsPreconditionFailed(err) {
+		return fmt.Errorf("%s was changed outside Terraform since it was last refreshed. Refresh and re-plan before applying again. (%s)", d.Id(), err)
+	}
+	return err
+}
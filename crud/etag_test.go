@@ -0,0 +1,80 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+)
+
+type eTagSync struct {
+	BaseCrud
+	Res *baremetal.Cpe
+}
+
+type ETagTestSuite struct {
+	suite.Suite
+	D *schema.ResourceData
+}
+
+func (s *ETagTestSuite) SetupTest() {
+	s.D = schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{"etag": ETagSchema}, map[string]interface{}{})
+	s.D.SetId("ocid1.cpe.oc1.phx.aaaa")
+}
+
+func (s *ETagTestSuite) TestSetETag() {
+	cpe := &baremetal.Cpe{}
+	cpe.ETag = "abc"
+	s.NoError(setETag(&eTagSync{BaseCrud: BaseCrud{D: s.D}, Res: cpe}))
+	s.Equal("abc", s.D.Get("etag"))
+
+	// Nothing read yet, nothing recorded.
+	s.NoError(setETag(&eTagSync{BaseCrud: BaseCrud{D: s.D}}))
+	s.Equal("abc", s.D.Get("etag"))
+
+	// Resources without an etag attribute don't record it.
+	d := schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{}, map[string]interface{}{})
+	s.NoError(setETag(&eTagSync{BaseCrud: BaseCrud{D: d}, Res: cpe}))
+}
+
+func (s *ETagTestSuite) TestSetETagFailure() {
+	d := schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{
+		"etag": {Type: schema.TypeInt, Computed: true},
+	}, map[string]interface{}{})
+	cpe := &baremetal.Cpe{}
+	cpe.ETag = "abc"
+	err := setETag(&eTagSync{BaseCrud: BaseCrud{D: d}, Res: cpe})
+	s.Error(err)
+	s.Contains(err.Error(), "Could not record the ETag")
+}
+
+func (s *ETagTestSuite) TestIfMatchIsOptIn() {
+	s.D.Set("etag", "abc")
+
+	sync := &BaseCrud{D: s.D}
+	s.Equal("", sync.IfMatch())
+	s.Nil(sync.IfMatchOptions())
+
+	sync.Client = &Client{Settings: Settings{UseETags: true}}
+	s.Equal("abc", sync.IfMatch())
+	s.Equal(&baremetal.IfMatchOptions{IfMatch: "abc"}, sync.IfMatchOptions())
+}
+
+func (s *ETagTestSuite) TestCheckPrecondition() {
+	err := checkPrecondition(s.D, &baremetal.Error{Status: "412", Code: "NoEtagMatch", Message: "changed"})
+	s.Contains(err.Error(), "ocid1.cpe.oc1.phx.aaaa was changed outside Terraform")
+	s.Contains(err.Error(), "re-plan")
+
+	other := errors.New("boom")
+	s.Equal(other, checkPrecondition(s.D, other))
+	conflict := &baremetal.Error{Status: "409", Code: "Conflict"}
+	s.Equal(conflict, checkPrecondition(s.D, conflict))
+}
+
+func TestETagTestSuite(t *testing.T) {
+	suite.Run(t, new(ETagTestSuite))
+}
@@ -4,9 +4,9 @@ package crud
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"reflect"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -18,7 +18,6 @@ import (
 )
 
 var (
-	MaxRetries     uint          = 6
 	FiveMinutes    time.Duration = 5 * time.Minute
 	TwoHours       time.Duration = 120 * time.Minute
 	DefaultTimeout               = &schema.ResourceTimeout{
@@ -31,6 +30,10 @@ var (
 type BaseCrud struct {
 	D      *schema.ResourceData
 	Client client.BareMetalClient
+	// RetryToken is sent as the opc-retry-token of create calls. It is the
+	// same for every attempt at one create, so that retrying a create the
+	// API already carried out doesn't make a second resource.
+	RetryToken string
 }
 
 func (s *BaseCrud) VoidState() {
@@ -68,21 +71,6 @@ func (s *BaseCrud) State() string {
 	return ""
 }
 
-func handleMissingResourceError(sync ResourceVoider, err *error) {
-
-	if err != nil {
-		if strings.Contains((*err).Error(), "does not exist") ||
-			strings.Contains((*err).Error(), " not present in ") ||
-			strings.Contains((*err).Error(), "not found") ||
-			(strings.Contains((*err).Error(), "Load balancer") && strings.Contains((*err).Error(), " has no ")) {
-
-			log.Println("[DEBUG] Object does not exist, voiding resource and nullifying error")
-			sync.VoidState()
-			*err = nil
-		}
-	}
-}
-
 func LoadBalancerResourceID(res interface{}, workReq *baremetal.WorkRequest) (id *string, workReqSucceeded bool) {
 	v := reflect.ValueOf(res).Elem()
 	if v.IsValid() {
@@ -146,6 +134,7 @@ func LoadBalancerResourceGet(s BaseCrud, workReq *baremetal.WorkRequest) (id str
 
 func LoadBalancerWaitForWorkRequest(client client.BareMetalClient, d *schema.ResourceData, wr *baremetal.WorkRequest) error {
 	var e error
+	settings := SettingsOf(client)
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{
 			baremetal.ResourceWaitingForWorkRequest,
@@ -158,45 +147,28 @@ func LoadBalancerWaitForWorkRequest(client client.BareMetalClient, d *schema.Res
 			baremetal.ResourceFailed,
 		},
 		Refresh: func() (interface{}, string, error) {
-			wr, e = client.GetWorkRequest(wr.ID, nil)
+			e = settings.Retry.Do(func() (err error) {
+				wr, err = client.GetWorkRequest(wr.ID, nil)
+				return
+			})
 			return wr, wr.State, e
 		},
-		Timeout: d.Timeout(schema.TimeoutCreate),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		PollInterval: settings.PollInterval,
 	}
 
 	if _, e = stateConf.WaitForState(); e != nil {
 		return e
 	}
-	if wr.State == baremetal.ResourceFailed {
-		return errors.New("Resource creation failed, state FAILED")
+	if wr.State == baremetal.WorkRequestFailed {
+		return workRequestError(wr)
 	}
 	return nil
 }
 
-func exponentialBackoffSleep(retryNum uint) {
-	secondsToSleep := 1 << retryNum
-	log.Printf("[DEBUG] Got a retriable error. Waiting %d seconds and trying again...", secondsToSleep)
-	time.Sleep(time.Duration(secondsToSleep) * time.Second)
-}
-
-func isRetriableError(error string) bool {
-	//all errors are retriable except 400, 401, 412, and 409 with code InvalidatedRetryToken
-	lowerCaseError := strings.ToLower(error)
-	statusMatch, _ := regexp.MatchString("status\\s*:\\s*4(0[01]|12)", lowerCaseError)
-	codeMatch, _ := regexp.MatchString("code\\s*:\\s*invalidatedretrytoken", lowerCaseError)
-	return !statusMatch && !codeMatch
-}
-
 func CreateResource(d *schema.ResourceData, sync ResourceCreator) (e error) {
-	return createResourceWithRetry(d, sync, 1)
-}
-
-func createResourceWithRetry(d *schema.ResourceData, sync ResourceCreator, retryNum uint) (e error) {
-	if e = sync.Create(); e != nil {
-		if isRetriableError(e.Error()) && retryNum <= MaxRetries {
-			exponentialBackoffSleep(retryNum)
-			e = createResourceWithRetry(d, sync, retryNum+1)
-		}
+	setRetryToken(sync)
+	if e = settingsFor(sync).Retry.Do(sync.Create); e != nil {
 		return e
 	}
 
@@ -204,37 +176,40 @@ func createResourceWithRetry(d *schema.ResourceData, sync ResourceCreator, retry
 	d.SetId(sync.ID())
 
 	if stateful, ok := sync.(StatefullyCreatedResource); ok {
-		e = waitForStateRefresh(stateful, d.Timeout(schema.TimeoutCreate), stateful.CreatedPending(), stateful.CreatedTarget())
+		e = waitForStateRefresh(stateful, d.Timeout(schema.TimeoutCreate), stateful.CreatedPending(), stateful.CreatedTarget(), "creation")
 	}
 
 	d.SetId(sync.ID())
 	sync.SetData()
-
-	if ew, waitOK := sync.(ExtraWaitPostCreateDelete); waitOK {
-		time.Sleep(ew.ExtraWaitPostCreateDelete())
+	if err := setETag(sync); err != nil && e == nil {
+		e = err
 	}
 
+	extraWait(sync)
+
 	return
 }
 
 func ReadResource(sync ResourceReader) (e error) {
-	return readResourceWithRetry(sync, 1)
-}
-
-func readResourceWithRetry(sync ResourceReader, retryNum uint) (e error) {
-	if e = sync.Get(); e != nil {
-		log.Printf("ERROR IN GET: %v\n", e.Error())
-		handleMissingResourceError(sync, &e)
-		if e != nil {
-			if isRetriableError(e.Error()) && retryNum <= MaxRetries {
-				exponentialBackoffSleep(retryNum)
-				e = readResourceWithRetry(sync, retryNum+1)
-			}
+	missing := false
+	e = settingsFor(sync).Retry.Do(func() (err error) {
+		missing = false
+		if err = sync.Get(); err != nil {
+			log.Printf("ERROR IN GET: %v\n", err.Error())
+			handleMissingResourceError(sync, &err)
+			missing = err == nil
 		}
 		return
+	})
+	// A resource that's gone has been voided, there's nothing to set.
+	if e != nil || missing {
+		return
 	}
 
 	sync.SetData()
+	if e = setETag(sync); e != nil {
+		return
+	}
 
 	/* Attempt at #113, but this breaks everything. Probably because this is used internally by other state checking mechanisms.
 
@@ -251,52 +226,59 @@ func readResourceWithRetry(sync ResourceReader, retryNum uint) (e error) {
 }
 
 func UpdateResource(d *schema.ResourceData, sync ResourceUpdater) (e error) {
-	return updateResourceWithRetry(d, sync, 1)
-}
-
-func updateResourceWithRetry(d *schema.ResourceData, sync ResourceUpdater, retryNum uint) (e error) {
 	d.Partial(true)
-	if e = sync.Update(); e != nil {
-		if isRetriableError(e.Error()) && retryNum <= MaxRetries {
-			exponentialBackoffSleep(retryNum)
-			e = updateResourceWithRetry(d, sync, retryNum+1)
-		}
-		return
+	if e = settingsFor(sync).Retry.Do(sync.Update); e != nil {
+		return checkPrecondition(d, e)
 	}
+	if stateful, ok := sync.(StatefullyUpdatedResource); ok {
+		e = waitForStateRefresh(stateful, d.Timeout(schema.TimeoutUpdate), stateful.UpdatedPending(), stateful.UpdatedTarget(), "update")
+	}
+
+	// Even if the update failed part way, save what the resource looks like now.
 	d.Partial(false)
 	sync.SetData()
+	if err := setETag(sync); err != nil && e == nil {
+		e = err
+	}
 
 	return
 }
 
+// extraWait gives the API time to catch up after sync is created or deleted.
+// A configured poll interval caps the wait, as it does the state refreshes.
+func extraWait(sync interface{}) {
+	ew, ok := sync.(ExtraWaitPostCreateDelete)
+	if !ok {
+		return
+	}
+	wait := ew.ExtraWaitPostCreateDelete()
+	if interval := settingsFor(sync).PollInterval; interval > 0 && interval < wait {
+		wait = interval
+	}
+	time.Sleep(wait)
+}
+
 // DeleteResource requests a Delete(). If the resource deletes
 // statefully (not immediately), poll State to ensure:
 // () -> Pending -> Deleted.
 // Finally, sets the ResourceData state to empty.
 func DeleteResource(d *schema.ResourceData, sync ResourceDeleter) (e error) {
-	return deleteResourceWithRetry(d, sync, 1)
-}
-
-func deleteResourceWithRetry(d *schema.ResourceData, sync ResourceDeleter, retryNum uint) (e error) {
-	if e = sync.Delete(); e != nil {
-		handleMissingResourceError(sync, &e)
-		if e != nil {
-			if isRetriableError(e.Error()) && retryNum <= MaxRetries {
-				exponentialBackoffSleep(retryNum)
-				e = deleteResourceWithRetry(d, sync, retryNum+1)
-			}
-			return
+	e = settingsFor(sync).Retry.Do(func() (err error) {
+		if err = sync.Delete(); err != nil {
+			handleMissingResourceError(sync, &err)
 		}
+		return
+	})
+	if e != nil {
+		return checkPrecondition(d, e)
 	}
 
 	//d.SetId(sync.ID())
 	if stateful, ok := sync.(StatefullyDeletedResource); ok {
-		e = waitForStateRefresh(stateful, d.Timeout(schema.TimeoutDelete), stateful.DeletedPending(), stateful.DeletedTarget())
+		e = waitForStateRefresh(stateful, d.Timeout(schema.TimeoutDelete), stateful.DeletedPending(), stateful.DeletedTarget(), "deletion")
 	}
 
-	if ew, waitOK := sync.(ExtraWaitPostCreateDelete); waitOK {
-		time.Sleep(ew.ExtraWaitPostCreateDelete())
-	}
+	extraWait(sync)
 
 	if e == nil {
 		sync.VoidState()
@@ -309,7 +291,7 @@ func deleteResourceWithRetry(d *schema.ResourceData, sync ResourceDeleter, retry
 
 func stateRefreshFunc(sync StatefulResource) resource.StateRefreshFunc {
 	return func() (res interface{}, s string, e error) {
-		if e = sync.Get(); e != nil {
+		if e = settingsFor(sync).Retry.Do(sync.Get); e != nil {
 			return nil, "", e
 		}
 		// We don't set all the state here, because not found errors are handled elsewhere.
@@ -322,16 +304,18 @@ func stateRefreshFunc(sync StatefulResource) resource.StateRefreshFunc {
 }
 
 // waitForStateRefresh takes a StatefulResource, a timeout duration, a list of states to treat as Pending, and a list of states to treat as Target. It uses those to wrap resource.StateChangeConf.WaitForState(). If the resource returns a missing status, it will not be treated as an error.
+// The operation being waited for, such as "creation", names it when the resource ends up FAILED.
 //
 // sync.D.Id must be set.
 // It does not set state from that refreshed state.
-func waitForStateRefresh(sync StatefulResource, timeout time.Duration, pending, target []string) (e error) {
+func waitForStateRefresh(sync StatefulResource, timeout time.Duration, pending, target []string, operation string) (e error) {
 	// TODO: try to move this onto sync
 	stateConf := &resource.StateChangeConf{
-		Pending: pending,
-		Target:  target,
-		Refresh: stateRefreshFunc(sync),
-		Timeout: timeout,
+		Pending:      pending,
+		Target:       target,
+		Refresh:      stateRefreshFunc(sync),
+		Timeout:      timeout,
+		PollInterval: settingsFor(sync).PollInterval,
 	}
 
 	if _, e = stateConf.WaitForState(); e != nil {
@@ -339,16 +323,61 @@ func waitForStateRefresh(sync StatefulResource, timeout time.Duration, pending,
 		return
 	}
 	if sync.State() == baremetal.ResourceFailed {
-		return errors.New("Resource creation failed, state FAILED")
+		if wr := failedWorkRequest(sync); wr != nil {
+			return workRequestError(wr)
+		}
+		if details := lifecycleDetails(sync); details != "" {
+			return fmt.Errorf("Resource %s failed, state FAILED: %s", operation, details)
+		}
+		return fmt.Errorf("Resource %s failed, state FAILED", operation)
 	}
 
 	return
 }
 
-func FilterMissingResourceError(sync ResourceVoider, err *error) {
-	if err != nil && strings.Contains((*err).Error(), "does not exist") {
-		log.Println("[DEBUG] Object does not exist, voiding resource and nullifying error")
-		sync.VoidState()
-		*err = nil
+// WaitForState polls sync until it reaches one of target, for changes made
+// outside of Create, Update and Delete, such as instance power actions.
+func WaitForState(sync StatefulResource, timeout time.Duration, pending, target []string) error {
+	return waitForStateRefresh(sync, timeout, pending, target, "state change")
+}
+
+// failedWorkRequest returns the failed work request that load balancer
+// resources track their changes with, if sync has one.
+func failedWorkRequest(sync interface{}) *baremetal.WorkRequest {
+	v := reflect.ValueOf(sync).Elem()
+	if field := v.FieldByName("WorkRequest"); field.IsValid() {
+		if wr, ok := field.Interface().(*baremetal.WorkRequest); ok && wr != nil && wr.State == baremetal.WorkRequestFailed {
+			return wr
+		}
+	}
+	return nil
+}
+
+// lifecycleDetails returns the explanation of its lifecycle state the API
+// gives for the resource of sync, if it gives one.
+func lifecycleDetails(sync interface{}) string {
+	v := reflect.ValueOf(sync).Elem()
+	if ref := v.FieldByName("Res"); ref.IsValid() && ref.Kind() == reflect.Ptr && !ref.IsNil() {
+		if details := ref.Elem().FieldByName("LifecycleDetails"); details.IsValid() && details.Kind() == reflect.String {
+			return details.String()
+		}
+	}
+	return ""
+}
+
+// workRequestError describes a failed load balancer work request with the
+// message and error details the API gives for it.
+func workRequestError(wr *baremetal.WorkRequest) error {
+	msg := fmt.Sprintf("Work request %s", wr.ID)
+	if wr.Type != "" {
+		msg += fmt.Sprintf(" (%s)", wr.Type)
+	}
+	msg += " failed"
+	if wr.Message != "" {
+		msg += ": " + wr.Message
+	}
+	for _, detail := range wr.ErrorDetails {
+		msg += fmt.Sprintf("\n  %s: %s", detail.ErrorCode, detail.Message)
 	}
+	return errors.New(msg)
 }
@@ -0,0 +1,151 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/suite"
+)
+
+type workRequestSync struct {
+	BaseCrud
+	WorkRequest *baremetal.WorkRequest
+}
+
+type WorkRequestErrorTestSuite struct {
+	suite.Suite
+}
+
+func (s *WorkRequestErrorTestSuite) TestMessageAndDetails() {
+	err := workRequestError(&baremetal.WorkRequest{
+		ID:      "ocid1.loadbalancerworkrequest.oc1.phx.aaaa",
+		Type:    "UpdateListener",
+		State:   baremetal.WorkRequestFailed,
+		Message: "UpdateListener failed",
+		ErrorDetails: []baremetal.WorkRequestError{
+			{ErrorCode: "BAD_INPUT", Message: "Invalid SSL configuration"},
+		},
+	})
+	s.Contains(err.Error(), "ocid1.loadbalancerworkrequest.oc1.phx.aaaa (UpdateListener) failed: UpdateListener failed")
+	s.Contains(err.Error(), "BAD_INPUT: Invalid SSL configuration")
+}
+
+func (s *WorkRequestErrorTestSuite) TestFailedWorkRequest() {
+	wr := &baremetal.WorkRequest{State: baremetal.WorkRequestFailed}
+	s.Equal(wr, failedWorkRequest(&workRequestSync{WorkRequest: wr}))
+
+	s.Nil(failedWorkRequest(&workRequestSync{}))
+	s.Nil(failedWorkRequest(&workRequestSync{WorkRequest: &baremetal.WorkRequest{State: baremetal.WorkRequestSucceeded}}))
+	s.Nil(failedWorkRequest(&BaseCrud{}))
+}
+
+// failedSync is a resource the API failed to launch.
+type failedSync struct {
+	BaseCrud
+	Res *baremetal.DBSystem
+}
+
+func (s *failedSync) Get() error {
+	s.Res = &baremetal.DBSystem{State: baremetal.ResourceFailed, LifecycleDetails: "Not enough cores"}
+	return nil
+}
+
+func (s *failedSync) SetData()      {}
+func (s *failedSync) State() string { return s.Res.State }
+
+func (s *WorkRequestErrorTestSuite) TestLifecycleDetails() {
+	d := schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{
+		"state": {Type: schema.TypeString, Computed: true},
+	}, map[string]interface{}{})
+	d.SetId("ocid1.dbsystem.oc1.phx.aaaa")
+
+	sync := &failedSync{BaseCrud: BaseCrud{D: d}}
+	err := waitForStateRefresh(sync, time.Minute, []string{baremetal.ResourceProvisioning}, []string{baremetal.ResourceAvailable, baremetal.ResourceFailed}, "creation")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "Resource creation failed, state FAILED: Not enough cores")
+
+	s.Equal("", lifecycleDetails(&failedSync{}))
+	s.Equal("", lifecycleDetails(&workRequestSync{}))
+}
+
+// failedUpdateSync is a resource the API failed to update.
+type failedUpdateSync struct {
+	failedSync
+	setData int
+}
+
+func (s *failedUpdateSync) Update() error { return nil }
+func (s *failedUpdateSync) SetData()      { s.setData++ }
+
+func (s *failedUpdateSync) UpdatedPending() []string {
+	return []string{baremetal.ResourceUpdating}
+}
+
+func (s *failedUpdateSync) UpdatedTarget() []string {
+	return []string{baremetal.ResourceAvailable, baremetal.ResourceFailed}
+}
+
+func (s *WorkRequestErrorTestSuite) TestFailedUpdate() {
+	sync := &failedUpdateSync{}
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"display_name": {Type: schema.TypeString, Optional: true},
+			"state":        {Type: schema.TypeString, Computed: true},
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			sync.D = d
+			return UpdateResource(d, sync)
+		},
+	}
+	state := &terraform.InstanceState{
+		ID:         "ocid1.dbsystem.oc1.phx.aaaa",
+		Attributes: map[string]string{"display_name": "old"},
+	}
+	diff := &terraform.InstanceDiff{
+		Attributes: map[string]*terraform.ResourceAttrDiff{
+			"display_name": {Old: "old", New: "new"},
+		},
+	}
+
+	_, err := r.Apply(state, diff, nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "Resource update failed, state FAILED: Not enough cores")
+	s.Equal(1, sync.setData, "The state after the failed update should still be saved")
+}
+
+// goneSync is a resource that was deleted outside of Terraform.
+type goneSync struct {
+	BaseCrud
+	Res *baremetal.Instance
+}
+
+func (s *goneSync) Get() error {
+	return &baremetal.Error{Status: "404", Code: "NotAuthorizedOrNotFound"}
+}
+
+func (s *goneSync) SetData() {
+	s.D.Set("state", s.Res.State)
+}
+
+func TestReadMissingResource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"state": {Type: schema.TypeString, Computed: true},
+	}, map[string]interface{}{})
+	d.SetId("ocid1.instance.oc1.phx.aaaa")
+
+	if err := ReadResource(&goneSync{BaseCrud: BaseCrud{D: d}}); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected the missing resource to be voided, got ID %q", d.Id())
+	}
+}
+
+func TestWorkRequestErrorTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkRequestErrorTestSuite))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"log"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+)
+
+const (
+	statusTooManyRequests = 429
+	invalidatedRetryToken = "InvalidatedRetryToken"
+)
+
+// RetryPolicy decides which failed API calls are retried and how long to
+// wait between attempts.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a call is made, including the first.
+	MaxAttempts uint
+	// BaseDelay is the wait after the first failure. It doubles after each
+	// further failure, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Jitter randomizes each wait between half and all of its length, so
+	// that parallel operations don't retry in lockstep.
+	Jitter bool
+	// RetryableStatusCodes are the HTTP statuses of API errors worth
+	// retrying. Throttled (429) calls are always retried, after the wait
+	// given by their Retry-After header when there is one.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy retries conflicts, throttling and server errors up to six
+// times, waiting 2s, 4s, 8s ... between attempts.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          7,
+		BaseDelay:            2 * time.Second,
+		MaxDelay:             time.Minute,
+		Jitter:               true,
+		RetryableStatusCodes: []int{409, 429, 500, 502, 503, 504},
+	}
+}
+
+// sleep is replaced by tests.
+var sleep = time.Sleep
+
+// shouldRetry classifies err. API errors are retried when their status is
+// retryable, transport errors always are, and anything else (validation done
+// by the provider, for example) never is.
+func (p *RetryPolicy) shouldRetry(err error) (retry bool, retryAfter time.Duration) {
+	switch e := err.(type) {
+	case *baremetal.Error:
+		if e.Code == invalidatedRetryToken {
+			return false, 0
+		}
+		status := StatusOf(e)
+		if status == statusTooManyRequests {
+			return true, e.RetryAfter
+		}
+		for _, code := range p.RetryableStatusCodes {
+			if status == code {
+				return true, 0
+			}
+		}
+		return false, 0
+	case net.Error:
+		return true, 0
+	}
+	return false, 0
+}
+
+// delay is the wait before attempt+1, attempts being counted from 1.
+func (p *RetryPolicy) delay(attempt uint) time.Duration {
+	d := p.BaseDelay
+	for i := uint(1); i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter && d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+	return d
+}
+
+// Do calls op until it succeeds, fails with an error that should not be
+// retried, or has been attempted MaxAttempts times.
+func (p *RetryPolicy) Do(op func() error) (e error) {
+	for attempt := uint(1); ; attempt++ {
+		if e = op(); e == nil {
+			return
+		}
+		retry, wait := p.shouldRetry(e)
+		if !retry || attempt >= p.MaxAttempts {
+			return
+		}
+		if wait == 0 {
+			wait = p.delay(attempt)
+		}
+		log.Printf("[DEBUG] Got a retriable error (%s). Waiting %s and trying again...", e, wait)
+		sleep(wait)
+	}
+}
+
+type retryTokenSetter interface {
+	setRetryToken(token string)
+}
+
+func (s *BaseCrud) setRetryToken(token string) {
+	s.RetryToken = token
+}
+
+// setRetryToken gives sync a new retry token for the create it is about to
+// make.
+func setRetryToken(sync interface{}) {
+	if setter, ok := sync.(retryTokenSetter); ok {
+		setter.setRetryToken(newRetryToken())
+	}
+}
+
+// newRetryToken returns a random opc-retry-token.
+func newRetryToken() string {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		// Creates can still be made without a token; they just aren't
+		// protected against duplicates.
+		log.Printf("[WARN] Could not generate a retry token: %s", err)
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
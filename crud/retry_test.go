@@ -0,0 +1,170 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryTestSuite struct {
+	suite.Suite
+	Policy *RetryPolicy
+	Sleeps []time.Duration
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.Policy = DefaultRetryPolicy()
+	s.Policy.Jitter = false
+	s.Sleeps = nil
+	sleep = func(d time.Duration) {
+		s.Sleeps = append(s.Sleeps, d)
+	}
+}
+
+func (s *RetryTestSuite) TearDownTest() {
+	sleep = time.Sleep
+}
+
+// failing returns an operation that fails with errs in turn, then succeeds.
+func failing(calls *int, errs ...error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func (s *RetryTestSuite) TestClassification() {
+	retryable := []error{
+		&baremetal.Error{Status: "429", Code: "TooManyRequests"},
+		&baremetal.Error{Status: "409", Code: "IncorrectState"},
+		&baremetal.Error{Status: "500", Code: "InternalServerError"},
+		&baremetal.Error{Status: "503", Code: "ServiceUnavailable"},
+		&net.OpError{Op: "dial", Err: errors.New("connection refused")},
+	}
+	for _, err := range retryable {
+		retry, _ := s.Policy.shouldRetry(err)
+		s.True(retry, err.Error())
+	}
+
+	fatal := []error{
+		&baremetal.Error{Status: "400", Code: "InvalidParameter"},
+		&baremetal.Error{Status: "401", Code: "NotAuthenticated"},
+		&baremetal.Error{Status: "404", Code: "NotAuthorizedOrNotFound"},
+		&baremetal.Error{Status: "412", Code: "NoEtagMatch"},
+		&baremetal.Error{Status: "409", Code: "InvalidatedRetryToken"},
+		// Errors that only look like retryable API errors.
+		errors.New("Status: 500; Code: InternalServerError"),
+	}
+	for _, err := range fatal {
+		retry, _ := s.Policy.shouldRetry(err)
+		s.False(retry, err.Error())
+	}
+}
+
+func (s *RetryTestSuite) TestBackoff() {
+	calls := 0
+	serverError := &baremetal.Error{Status: "500"}
+	err := s.Policy.Do(failing(&calls, serverError, serverError, serverError))
+	s.NoError(err)
+	s.Equal(4, calls)
+	s.Equal([]time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}, s.Sleeps)
+}
+
+func (s *RetryTestSuite) TestMaxDelay() {
+	s.Policy.MaxDelay = 5 * time.Second
+	s.Equal(2*time.Second, s.Policy.delay(1))
+	s.Equal(4*time.Second, s.Policy.delay(2))
+	s.Equal(5*time.Second, s.Policy.delay(3))
+	s.Equal(5*time.Second, s.Policy.delay(60))
+}
+
+func (s *RetryTestSuite) TestJitter() {
+	s.Policy.Jitter = true
+	for i := 0; i < 20; i++ {
+		d := s.Policy.delay(3)
+		s.True(d >= 4*time.Second && d <= 8*time.Second, d.String())
+	}
+}
+
+func (s *RetryTestSuite) TestMaxAttempts() {
+	s.Policy.MaxAttempts = 3
+	calls := 0
+	serverError := &baremetal.Error{Status: "503"}
+	err := s.Policy.Do(failing(&calls, serverError, serverError, serverError, serverError))
+	s.Equal(serverError, err)
+	s.Equal(3, calls)
+	s.Len(s.Sleeps, 2)
+}
+
+func (s *RetryTestSuite) TestNotRetried() {
+	calls := 0
+	notFound := &baremetal.Error{Status: "404"}
+	err := s.Policy.Do(failing(&calls, notFound))
+	s.Equal(notFound, err)
+	s.Equal(1, calls)
+	s.Empty(s.Sleeps)
+}
+
+func (s *RetryTestSuite) TestThrottlingHonoursRetryAfter() {
+	// Throttled calls are retried even when 429 isn't listed.
+	s.Policy.RetryableStatusCodes = []int{503}
+	calls := 0
+	err := s.Policy.Do(failing(&calls,
+		&baremetal.Error{Status: "429", RetryAfter: 30 * time.Second},
+		&baremetal.Error{Status: "429"},
+	))
+	s.NoError(err)
+	s.Equal(3, calls)
+	s.Equal([]time.Duration{30 * time.Second, 4 * time.Second}, s.Sleeps)
+}
+
+// createSync records the retry token of every create attempt.
+type createSync struct {
+	BaseCrud
+	Tokens []string
+	errs   []error
+}
+
+func (s *createSync) ID() string { return "ocid1.vcn.oc1.phx.aaaa" }
+func (s *createSync) SetData()   {}
+
+func (s *createSync) Create() (e error) {
+	s.Tokens = append(s.Tokens, s.RetryToken)
+	if len(s.errs) > 0 {
+		e, s.errs = s.errs[0], s.errs[1:]
+	}
+	return
+}
+
+func (s *RetryTestSuite) TestCreateReusesRetryToken() {
+	d := schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{}, map[string]interface{}{})
+	api := &Client{Settings: Settings{Retry: s.Policy}}
+	first := &createSync{BaseCrud: BaseCrud{D: d, Client: api}, errs: []error{
+		&net.OpError{Op: "read", Err: errors.New("i/o timeout")},
+		&baremetal.Error{Status: "503"},
+	}}
+	s.NoError(CreateResource(d, first))
+	s.Len(first.Tokens, 3)
+	s.NotEmpty(first.Tokens[0])
+	s.Equal(first.Tokens[0], first.Tokens[1])
+	s.Equal(first.Tokens[0], first.Tokens[2])
+
+	// Each create gets its own token.
+	second := &createSync{BaseCrud: BaseCrud{D: d, Client: api}}
+	s.NoError(CreateResource(d, second))
+	s.NotEqual(first.Tokens[0], second.Tokens[0])
+}
+
+func TestRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"time"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+)
+
+// Settings are the provider settings that change how the CRUD helpers call
+// the API.
+type Settings struct {
+	// Retry decides which failed calls are retried. DefaultRetryPolicy is
+	// used when it is nil.
+	Retry *RetryPolicy
+	// UseETags makes updates and deletes conditional on the resource being
+	// as it was when Terraform last read it.
+	UseETags bool
+	// PollInterval overrides the backoff between state refreshes and caps
+	// the extra waits after creates and deletes when set. Replayed tests use
+	// it to skip the real API's provisioning time.
+	PollInterval time.Duration
+}
+
+// Client is what the provider hands its resources: the API client together
+// with the settings of the provider block it was configured from. Keeping
+// the settings here rather than in package variables lets aliased provider
+// blocks use different ones.
+type Client struct {
+	client.BareMetalClient
+	Settings
+}
+
+func (c *Client) settings() Settings {
+	return c.Settings
+}
+
+type settingsHolder interface {
+	settings() Settings
+}
+
+// SettingsOf returns the settings c was configured with, or the defaults for
+// clients that weren't configured by the provider, such as test mocks.
+func SettingsOf(c interface{}) (s Settings) {
+	if holder, ok := c.(settingsHolder); ok {
+		s = holder.settings()
+	}
+	if s.Retry == nil {
+		s.Retry = DefaultRetryPolicy()
+	}
+	return
+}
+
+// settingsFor returns the settings of the client sync uses.
+func settingsFor(sync interface{}) Settings {
+	if base, ok := sync.(interface {
+		baseClient() client.BareMetalClient
+	}); ok {
+		return SettingsOf(base.baseClient())
+	}
+	return SettingsOf(nil)
+}
+
+func (s *BaseCrud) baseClient() client.BareMetalClient {
+	return s.Client
+}
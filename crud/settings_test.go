@@ -0,0 +1,60 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package crud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
+)
+
+type SettingsTestSuite struct {
+	suite.Suite
+}
+
+func (s *SettingsTestSuite) TestDefaults() {
+	settings := SettingsOf(&mocks.BareMetalClient{})
+	s.Equal(DefaultRetryPolicy(), settings.Retry)
+	s.False(settings.UseETags)
+	s.Zero(settings.PollInterval)
+
+	s.Equal(DefaultRetryPolicy(), SettingsOf(&Client{}).Retry)
+}
+
+func (s *SettingsTestSuite) TestEachClientKeepsItsSettings() {
+	once := &RetryPolicy{MaxAttempts: 1}
+	first := &Client{BareMetalClient: &mocks.BareMetalClient{}, Settings: Settings{Retry: once, UseETags: true}}
+	second := &Client{BareMetalClient: &mocks.BareMetalClient{}, Settings: Settings{PollInterval: time.Millisecond}}
+
+	d := schema.TestResourceDataRaw(s.T(), map[string]*schema.Schema{}, map[string]interface{}{})
+	s.Equal(first.Settings, settingsFor(&createSync{BaseCrud: BaseCrud{D: d, Client: first}}))
+
+	settings := settingsFor(&createSync{BaseCrud: BaseCrud{D: d, Client: second}})
+	s.Equal(DefaultRetryPolicy(), settings.Retry)
+	s.False(settings.UseETags)
+	s.Equal(time.Millisecond, settings.PollInterval)
+}
+
+type settlingSync struct {
+	BaseCrud
+}
+
+func (s *settlingSync) ExtraWaitPostCreateDelete() time.Duration {
+	return time.Hour
+}
+
+func (s *SettingsTestSuite) TestPollIntervalCapsExtraWait() {
+	sync := &settlingSync{BaseCrud{Client: &Client{Settings: Settings{PollInterval: time.Millisecond}}}}
+
+	start := time.Now()
+	extraWait(sync)
+	s.True(time.Since(start) < time.Second)
+}
+
+func TestSettingsTestSuite(t *testing.T) {
+	suite.Run(t, new(SettingsTestSuite))
+}
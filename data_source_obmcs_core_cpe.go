@@ -29,6 +29,7 @@ func CpeDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"cpes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -39,6 +40,9 @@ func CpeDatasource() *schema.Resource {
 }
 
 func readCpeList(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	reader := &CPEDatasourceCrud{}
 	reader.D = d
 	reader.Client = m.(client.BareMetalClient)
@@ -93,6 +97,7 @@ func (s *CPEDatasourceCrud) SetData() {
 			cpes = append(cpes, cpe)
 		}
 
+		cpes = options.ApplyFilters(s.D, cpes)
 		s.D.Set("cpes", cpes)
 
 	}
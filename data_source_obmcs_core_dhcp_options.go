@@ -29,6 +29,7 @@ func DHCPOptionsDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"options": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func DHCPOptionsDatasource() *schema.Resource {
 }
 
 func readDHCPOptionsList(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &DHCPOptionsDatasourceCrud{}
 	reader.D = d
@@ -109,6 +113,7 @@ func (s *DHCPOptionsDatasourceCrud) SetData() {
 			}
 			stateObjs = append(stateObjs, stateObj)
 		}
+		stateObjs = options.ApplyFilters(s.D, stateObjs)
 		s.D.Set("options", stateObjs)
 	}
 	return
@@ -29,6 +29,7 @@ func DrgDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"drgs": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -39,6 +40,9 @@ func DrgDatasource() *schema.Resource {
 }
 
 func readDrgs(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DrgDatasourceCrud{}
 	sync.D = d
@@ -78,9 +82,9 @@ func (s *DrgDatasourceCrud) Get() (e error) {
 func (s *DrgDatasourceCrud) SetData() {
 	if s.Res != nil {
 		s.D.SetId(time.Now().UTC().String())
-		resources := []map[string]string{}
+		resources := []map[string]interface{}{}
 		for _, v := range s.Res.Drgs {
-			res := map[string]string{
+			res := map[string]interface{}{
 				"compartment_id": v.CompartmentID,
 				"display_name":   v.DisplayName,
 				"id":             v.ID,
@@ -89,6 +93,7 @@ func (s *DrgDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("drgs", resources)
 	}
 	return
@@ -37,6 +37,7 @@ func DrgAttachmentDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"drg_attachments": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -47,6 +48,9 @@ func DrgAttachmentDatasource() *schema.Resource {
 }
 
 func readDrgAttachments(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DrgAttachmentDatasourceCrud{}
 	sync.D = d
@@ -96,9 +100,9 @@ func (s *DrgAttachmentDatasourceCrud) SetData() {
 		// Important, if you don't have an ID, make one up for your datasource
 		// or things will end in tears
 		s.D.SetId(time.Now().UTC().String())
-		resources := []map[string]string{}
+		resources := []map[string]interface{}{}
 		for _, v := range s.Res.DrgAttachments {
-			res := map[string]string{
+			res := map[string]interface{}{
 				"compartment_id": v.CompartmentID,
 				"display_name":   v.DisplayName,
 				"drg_id":         v.DrgID,
@@ -109,6 +113,7 @@ func (s *DrgAttachmentDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("drg_attachments", resources)
 	}
 	return
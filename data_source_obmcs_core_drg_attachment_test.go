@@ -47,7 +47,7 @@ func (s *CoreDrgAttachmentDatasourceTestSuite) SetupTest() {
 	}
     data "baremetal_core_drg_attachments" "t" {
         compartment_id = "${var.compartment_id}"
-	drg_id = "${baremetal_core_drg.t.id}"
+	drg_id = "${baremetal_core_drg_attachment.t.drg_id}"
         limit = 1
 	vcn_id = "${baremetal_core_virtual_network.t.id}"
     }
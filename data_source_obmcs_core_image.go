@@ -41,6 +41,7 @@ func ImageDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"images": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -51,6 +52,9 @@ func ImageDatasource() *schema.Resource {
 }
 
 func readImages(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &ImageDatasourceCrud{}
 	sync.D = d
@@ -101,19 +105,9 @@ func (s *ImageDatasourceCrud) SetData() {
 		s.D.SetId(time.Now().UTC().String())
 		resources := []map[string]interface{}{}
 		for _, v := range s.Res.Images {
-			res := map[string]interface{}{
-				"base_image_id":            v.BaseImageID,
-				"compartment_id":           v.CompartmentID,
-				"create_image_allowed":     v.CreateImageAllowed,
-				"display_name":             v.DisplayName,
-				"id":                       v.ID,
-				"state":                    v.State,
-				"operating_system":         v.OperatingSystem,
-				"operating_system_version": v.OperatingSystemVersion,
-				"time_created":             v.TimeCreated.String(),
-			}
-			resources = append(resources, res)
+			resources = append(resources, imageMap(v))
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("images", resources)
 	}
 	return
@@ -0,0 +1,98 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// ImageLookupDatasource finds the one image that matches its arguments and
+// filters.
+func ImageLookupDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readImageLookup,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"operating_system": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"operating_system_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"filter": options.FilterSchema(),
+			// Computed
+			"base_image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"create_image_allowed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readImageLookup(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &ImageLookupDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type ImageLookupDatasourceCrud struct {
+	ImageDatasourceCrud
+	Item map[string]interface{}
+}
+
+func (s *ImageLookupDatasourceCrud) Get() (e error) {
+	if e = s.ImageDatasourceCrud.Get(); e != nil {
+		return
+	}
+
+	images := []map[string]interface{}{}
+	created := []baremetal.Time{}
+	for _, v := range s.Res.Images {
+		images = append(images, imageMap(v))
+		created = append(created, v.TimeCreated)
+	}
+	s.Item, e = options.SelectOne(s.D, "image", images, created, s.D.Get("most_recent").(bool))
+	return
+}
+
+func (s *ImageLookupDatasourceCrud) SetData() {
+	options.SetItem(s.D, s.Item)
+}
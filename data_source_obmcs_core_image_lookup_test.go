@@ -0,0 +1,111 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CoreImageLookupDatasourceTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *CoreImageLookupDatasourceTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+	s.Provider = Provider(func(d *schema.ResourceData) (interface{}, error) {
+		return s.Client, nil
+	})
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+	s.ResourceName = "data.baremetal_core_image.t"
+}
+
+func (s *CoreImageLookupDatasourceTestSuite) config(args string) string {
+	return `
+    data "baremetal_core_image" "t" {
+      compartment_id = "${var.compartment_id}"
+      ` + args + `
+    }
+  ` + testProviderConfig()
+}
+
+func (s *CoreImageLookupDatasourceTestSuite) TestLookupImage() {
+	if !IsFakeAPITest() {
+		s.T().Skip("Checks the images the fake API is seeded with")
+	}
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.config(`
+				  operating_system = "Oracle Linux"
+				  filter {
+				    name = "operating_system_version"
+				    values = ["7.3"]
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "id", "ocid1.image.oc1.phx.platformimage0"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "Oracle-Linux-7.3-2017.05.23-0"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", "AVAILABLE"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "time_created"),
+				),
+			},
+			// The ID is the image's, so reading it again changes nothing.
+			{
+				Config: s.config(`
+				  operating_system = "Oracle Linux"
+				  filter {
+				    name = "operating_system_version"
+				    values = ["7.3"]
+				  }`),
+				PlanOnly: true,
+			},
+		},
+	},
+	)
+}
+
+func (s *CoreImageLookupDatasourceTestSuite) TestAmbiguousImage() {
+	if !IsFakeAPITest() {
+		s.T().Skip("Checks the images the fake API is seeded with")
+	}
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      s.config(""),
+				ExpectError: regexp.MustCompile("2 images match the arguments and filters"),
+			},
+			{
+				Config:      s.config(`filter { name = "display_name", values = ["^Windows"], regex = true }`),
+				ExpectError: regexp.MustCompile("No image matches the arguments and filters"),
+			},
+			{
+				Config: s.config("most_recent = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(s.ResourceName, "id", regexp.MustCompile("^ocid1.image.oc1.phx.platformimage")),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestCoreImageLookupDatasourceTestSuite(t *testing.T) {
+	suite.Run(t, new(CoreImageLookupDatasourceTestSuite))
+}
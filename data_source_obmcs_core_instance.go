@@ -33,6 +33,7 @@ func InstanceDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"instances": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -95,6 +96,9 @@ func resourceCoreInstance() *schema.Resource {
 }
 
 func readInstances(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &InstanceDatasourceCrud{}
 	reader.D = d
@@ -157,6 +161,7 @@ func (s *InstanceDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("instances", resources)
 	}
 	return
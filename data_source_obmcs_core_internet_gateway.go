@@ -33,6 +33,7 @@ func InternetGatewayDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"gateways": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func InternetGatewayDatasource() *schema.Resource {
 }
 
 func readInternetGateways(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &InternetGatewayDatasourceCrud{}
 	reader.D = d
@@ -103,6 +107,7 @@ func (s InternetGatewayDatasourceCrud) SetData() {
 			resources = append(resources, resource)
 		}
 
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("gateways", resources)
 
 	}
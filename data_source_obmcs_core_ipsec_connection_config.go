@@ -8,6 +8,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func IPSecConnectionConfigDatasource() *schema.Resource {
@@ -30,6 +31,7 @@ func IPSecConnectionConfigDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": options.FilterSchema(),
 			"tunnels": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -55,6 +57,9 @@ func IPSecConnectionConfigDatasource() *schema.Resource {
 }
 
 func readIPSecDeviceConfig(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &IPSecConnectionConfigDatasourceCrud{}
 	reader.D = d
@@ -93,6 +98,7 @@ func (s *IPSecConnectionConfigDatasourceCrud) SetData() {
 			tunnels = append(tunnels, tunnel)
 		}
 
+		tunnels = options.ApplyFilters(s.D, tunnels)
 		s.D.Set("tunnels", tunnels)
 
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func IPSecConnectionStatusDatasource() *schema.Resource {
@@ -30,6 +31,7 @@ func IPSecConnectionStatusDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": options.FilterSchema(),
 			"tunnels": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -59,6 +61,9 @@ func IPSecConnectionStatusDatasource() *schema.Resource {
 }
 
 func readIPSecDeviceStatus(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &IPSecConnectionStatusDatasourceCrud{}
 	reader.D = d
@@ -98,6 +103,7 @@ func (s *IPSecConnectionStatusDatasourceCrud) SetData() {
 			tunnels = append(tunnels, tunnel)
 		}
 
+		tunnels = options.ApplyFilters(s.D, tunnels)
 		s.D.Set("tunnels", tunnels)
 
 	}
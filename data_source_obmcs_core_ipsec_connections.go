@@ -37,6 +37,7 @@ func IPSecConnectionsDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"connections": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -89,6 +90,9 @@ func datasourceIPSecConnections() *schema.Resource {
 }
 
 func readIPSecConnections(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &IPSecConnectionsDatasourceCrud{}
 	reader.D = d
@@ -155,6 +159,7 @@ func (s IPSecConnectionsDatasourceCrud) SetData() {
 			resources = append(resources, resource)
 		}
 
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("connections", resources)
 
 	}
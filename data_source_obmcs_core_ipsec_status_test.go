@@ -67,7 +67,7 @@ func (s *DatasourceCoreIPSecStatusTestSuite) TestIPSecStatus() {
 				Config:            s.Config,
 				Check: resource.ComposeTestCheckFunc(
 
-					resource.TestCheckResourceAttr(s.ResourceName, "id", "id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "baremetal_core_ipsec.t", "id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "tunnels.#"),
 				),
 			},
@@ -49,7 +49,7 @@ func (s *DatasourceCoreIPSecTestSuite) SetupTest() {
 	}
 	data "baremetal_core_ipsec_connections" "s" {
 	      compartment_id = "${var.compartment_id}"
-	      cpe_id = "${baremetal_core_cpe.t.id}"
+	      cpe_id = "${baremetal_core_ipsec.t.cpe_id}"
 	}
   `
 	s.Config += testProviderConfig()
@@ -67,7 +67,7 @@ func (s *DatasourceCoreIPSecTestSuite) TestResourceListIPConnections() {
 				ImportStateVerify: true,
 				Config:            s.Config,
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttrSet(s.ResourceName, "drg_id"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "connections.0.drg_id", "baremetal_core_drg.t", "id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "connections.0.compartment_id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "connections.0.id"),
 					resource.TestCheckResourceAttrSet(s.ResourceName, "connections.#"),
@@ -29,6 +29,7 @@ func RouteTableDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"route_tables": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func RouteTableDatasource() *schema.Resource {
 }
 
 func readRouteTables(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &RouteTableDatasourceCrud{}
 	reader.D = d
@@ -87,13 +91,14 @@ func (s *RouteTableDatasourceCrud) SetData() {
 		resources := []map[string]interface{}{}
 		for _, v := range s.Res.RouteTables {
 
-			rules := []map[string]interface{}{}
+			// Nested sets have to be set as sets.
+			rules := schema.NewSet(routeRuleHash, nil)
 			for _, val := range v.RouteRules {
 				rule := map[string]interface{}{
 					"cidr_block":        val.CidrBlock,
 					"network_entity_id": val.NetworkEntityID,
 				}
-				rules = append(rules, rule)
+				rules.Add(rule)
 			}
 
 			res := map[string]interface{}{
@@ -107,6 +112,7 @@ func (s *RouteTableDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("route_tables", resources)
 	}
 	return
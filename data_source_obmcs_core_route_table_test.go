@@ -80,6 +80,31 @@ func (s *ResourceCoreRouteTablesTestSuite) TestResourceListRouteTables() {
 	)
 }
 
+func (s *ResourceCoreRouteTablesTestSuite) TestFilterRouteTables() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				    data "baremetal_core_route_tables" "filtered" {
+				      compartment_id = "${var.compartment_id}"
+				      vcn_id = "${baremetal_core_route_table.t.vcn_id}"
+				      filter {
+				        name = "route_rules.network_entity_id"
+				        values = ["${baremetal_core_internet_gateway.CompleteIG.id}"]
+				      }
+				    }`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.baremetal_core_route_tables.filtered", "route_tables.#", "1"),
+					resource.TestCheckResourceAttr("data.baremetal_core_route_tables.filtered", "route_tables.0.display_name", "display_name"),
+				),
+			},
+		},
+	},
+	)
+}
+
 func TestResourceCoreRouteTablesTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreRouteTablesTestSuite))
 }
@@ -33,6 +33,7 @@ func SecurityListDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"security_lists": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func SecurityListDatasource() *schema.Resource {
 }
 
 func readSecurityLists(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &SecurityListDatasourceCrud{}
 	sync.D = d
@@ -95,7 +99,8 @@ func (s *SecurityListDatasourceCrud) SetData() {
 				"vcn_id":         v.VcnID,
 			}
 
-			confEgressRules := []map[string]interface{}{}
+			// Nested sets have to be set as sets.
+			confEgressRules := schema.NewSet(egressRuleHash, nil)
 			for _, egressRule := range v.EgressSecurityRules {
 				confEgressRule := map[string]interface{}{}
 				confEgressRule["destination"] = egressRule.Destination
@@ -107,11 +112,11 @@ func (s *SecurityListDatasourceCrud) SetData() {
 					egressRule.UDPOptions,
 					&egressRule.IsStateless,
 				)
-				confEgressRules = append(confEgressRules, confEgressRule)
+				confEgressRules.Add(confEgressRule)
 			}
 			res["egress_security_rules"] = confEgressRules
 
-			confIngressRules := []map[string]interface{}{}
+			confIngressRules := schema.NewSet(ingressRuleHash, nil)
 			for _, ingressRule := range v.IngressSecurityRules {
 				confIngressRule := map[string]interface{}{}
 				confIngressRule["source"] = ingressRule.Source
@@ -121,14 +126,15 @@ func (s *SecurityListDatasourceCrud) SetData() {
 					ingressRule.ICMPOptions,
 					ingressRule.TCPOptions,
 					ingressRule.UDPOptions,
-					nil,
+					&ingressRule.IsStateless,
 				)
-				confIngressRules = append(confIngressRules, confIngressRule)
+				confIngressRules.Add(confIngressRule)
 			}
 			res["ingress_security_rules"] = confIngressRules
 
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("security_lists", resources)
 	}
 	return
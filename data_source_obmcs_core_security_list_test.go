@@ -3,8 +3,11 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -65,6 +68,17 @@ resource "baremetal_core_security_list" "WebSubnet" {
 }
 
 func (s *CoreSecurityListDatasourceTestSuite) TestReadSecurityLists() {
+	// Rules are keyed by the hash of the rule SetData builds from the API's.
+	stateless := false
+	webRule := ingressRuleHash(buildConfRule(
+		map[string]interface{}{"source": "0.0.0.0/0"},
+		"6",
+		nil,
+		&baremetal.TCPOptions{DestinationPortRange: baremetal.PortRange{Min: 80, Max: 80}},
+		nil,
+		&stateless,
+	))
+
 	resource.UnitTest(s.T(), resource.TestCase{
 		PreventPostDestroyRefresh: true,
 		Providers:                 s.Providers,
@@ -79,13 +93,92 @@ func (s *CoreSecurityListDatasourceTestSuite) TestReadSecurityLists() {
 				    data "baremetal_core_security_lists" "t" {
 				      compartment_id = "${var.compartment_id}"
 				      limit = 1
-				      vcn_id = "${baremetal_core_virtual_network.t.id}"
+				      vcn_id = "${baremetal_core_security_list.WebSubnet.vcn_id}"
+				    }
+				    data "baremetal_core_security_lists" "public" {
+				      compartment_id = "${var.compartment_id}"
+				      vcn_id = "${baremetal_core_security_list.WebSubnet.vcn_id}"
+				      filter {
+				        name = "display_name"
+				        values = ["Public"]
+				      }
 				    }`,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet(s.ResourceName, "vcn_id"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "security_lists.0.ingress_security_rules.0.tcp_options.0.max"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "security_lists.0.id"),
 					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.#", "2"),
+					resource.TestCheckResourceAttr("data.baremetal_core_security_lists.public", "security_lists.#", "1"),
+					resource.TestCheckResourceAttrPair("data.baremetal_core_security_lists.public", "security_lists.0.id", "baremetal_core_security_list.WebSubnet", "id"),
+					resource.TestCheckResourceAttr("data.baremetal_core_security_lists.public", "security_lists.0.ingress_security_rules.#", "2"),
+					resource.TestCheckResourceAttr("data.baremetal_core_security_lists.public", fmt.Sprintf("security_lists.0.ingress_security_rules.%d.tcp_options.0.max", webRule), "80"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func (s *CoreSecurityListDatasourceTestSuite) TestFilterSecurityLists() {
+	lists := func(filters string) string {
+		return s.Config + `
+		    data "baremetal_core_security_lists" "t" {
+		      compartment_id = "${var.compartment_id}"
+		      vcn_id = "${baremetal_core_security_list.WebSubnet.vcn_id}"
+		      ` + filters + `
+		    }`
+	}
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+			},
+			{
+				Config: lists(`
+				  filter {
+				    name = "display_name"
+				    values = ["("]
+				    regex = true
+				  }`),
+				ExpectError: regexp.MustCompile("Invalid regular expression"),
+			},
+			{
+				Config: lists(`
+				  filter {
+				    name = "display_name"
+				    values = ["Public"]
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.0.display_name", "Public"),
+				),
+			},
+			{
+				Config: lists(`
+				  filter {
+				    name = "ingress_security_rules.source"
+				    values = ["10.0.0.0/16"]
+				  }
+				  filter {
+				    name = "state"
+				    values = ["AVAILABLE"]
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.0.display_name", "Public"),
+				),
+			},
+			{
+				Config: lists(`
+				  filter {
+				    name = "display_name"
+				    values = ["^Default "]
+				    regex = true
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "security_lists.#", "1"),
+					resource.TestMatchResourceAttr(s.ResourceName, "security_lists.0.display_name", regexp.MustCompile("^Default ")),
 				),
 			},
 		},
@@ -17,6 +17,7 @@ func InstanceShapeDatasource() *schema.Resource {
 	return &schema.Resource{
 		Read: readInstanceShape,
 		Schema: map[string]*schema.Schema{
+			"filter": options.FilterSchema(),
 			"shapes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -54,6 +55,9 @@ func InstanceShapeDatasource() *schema.Resource {
 }
 
 func readInstanceShape(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &InstanceShapeDatasourceCrud{}
 	reader.D = d
@@ -103,13 +107,14 @@ func (r *InstanceShapeDatasourceCrud) SetData() {
 		// Important, if you don't have an ID, make one up for your datasource
 		// or things will end in tears
 		r.D.SetId(time.Now().UTC().String())
-		shapes := []map[string]string{}
+		shapes := []map[string]interface{}{}
 		for _, v := range r.Res.Shapes {
-			shape := map[string]string{
+			shape := map[string]interface{}{
 				"name": v.Name,
 			}
 			shapes = append(shapes, shape)
 		}
+		shapes = options.ApplyFilters(r.D, shapes)
 		r.D.Set("shapes", shapes)
 	}
 	return
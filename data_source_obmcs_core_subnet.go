@@ -33,6 +33,7 @@ func SubnetDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"subnets": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -105,6 +106,9 @@ func resourceCoreSubnets() *schema.Resource {
 }
 
 func readSubnets(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &SubnetDatasourceCrud{}
 	reader.D = d
@@ -149,23 +153,9 @@ func (s *SubnetDatasourceCrud) SetData() {
 		s.D.SetId(time.Now().UTC().String())
 		resources := []map[string]interface{}{}
 		for _, v := range s.Res.Subnets {
-			res := map[string]interface{}{
-				"availability_domain": v.AvailabilityDomain,
-				"cidr_block":          v.CIDRBlock,
-				"compartment_id":      v.CompartmentID,
-				"route_table_id":      v.RouteTableID,
-				"vcn_id":              v.VcnID,
-				"security_list_ids":   v.SecurityListIDs,
-				"display_name":        v.DisplayName,
-				"id":                  v.ID,
-				"prohibit_public_ip_on_vnic": v.ProhibitPublicIpOnVnic,
-				"state":              v.State,
-				"time_created":       v.TimeCreated.String(),
-				"virtual_router_ip":  v.VirtualRouterIP,
-				"virtual_router_mac": v.VirtualRouterMac,
-			}
-			resources = append(resources, res)
+			resources = append(resources, subnetMap(v))
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("subnets", resources)
 	}
 	return
@@ -0,0 +1,64 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// SubnetLookupDatasource finds the one subnet of a VCN that matches its
+// filters.
+func SubnetLookupDatasource() *schema.Resource {
+	subnet := resourceCoreSubnets().Schema
+	subnet["compartment_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	subnet["vcn_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	delete(subnet, "id")
+	subnet["filter"] = options.FilterSchema()
+
+	return &schema.Resource{
+		Read:   readSubnetLookup,
+		Schema: subnet,
+	}
+}
+
+func readSubnetLookup(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &SubnetLookupDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type SubnetLookupDatasourceCrud struct {
+	SubnetDatasourceCrud
+	Item map[string]interface{}
+}
+
+func (s *SubnetLookupDatasourceCrud) Get() (e error) {
+	if e = s.SubnetDatasourceCrud.Get(); e != nil {
+		return
+	}
+
+	subnets := []map[string]interface{}{}
+	for _, v := range s.Res.Subnets {
+		subnets = append(subnets, subnetMap(v))
+	}
+	s.Item, e = options.SelectOne(s.D, "subnet", subnets, nil, false)
+	return
+}
+
+func (s *SubnetLookupDatasourceCrud) SetData() {
+	options.SetItem(s.D, s.Item)
+}
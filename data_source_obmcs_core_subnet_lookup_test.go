@@ -0,0 +1,98 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CoreSubnetLookupDatasourceTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *CoreSubnetLookupDatasourceTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+	s.Provider = Provider(func(d *schema.ResourceData) (interface{}, error) {
+		return s.Client, nil
+	})
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+	s.Config = `
+data "baremetal_identity_availability_domains" "ADs" {
+	compartment_id = "${var.compartment_id}"
+}
+resource "baremetal_core_virtual_network" "t" {
+	cidr_block = "10.0.0.0/16"
+	compartment_id = "${var.compartment_id}"
+	display_name = "network_name"
+}
+resource "baremetal_core_subnet" "public" {
+	availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	compartment_id = "${var.compartment_id}"
+	display_name = "public"
+	cidr_block = "10.0.1.0/24"
+	vcn_id = "${baremetal_core_virtual_network.t.id}"
+	route_table_id = "${baremetal_core_virtual_network.t.default_route_table_id}"
+	security_list_ids = ["${baremetal_core_virtual_network.t.default_security_list_id}"]
+}
+resource "baremetal_core_subnet" "private" {
+	availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	compartment_id = "${var.compartment_id}"
+	display_name = "private"
+	cidr_block = "10.0.2.0/24"
+	vcn_id = "${baremetal_core_subnet.public.vcn_id}"
+	route_table_id = "${baremetal_core_virtual_network.t.default_route_table_id}"
+	security_list_ids = ["${baremetal_core_virtual_network.t.default_security_list_id}"]
+}
+`
+	s.Config += testProviderConfig()
+	s.ResourceName = "data.baremetal_core_subnet.t"
+}
+
+func (s *CoreSubnetLookupDatasourceTestSuite) lookup(filters string) string {
+	return s.Config + `
+    data "baremetal_core_subnet" "t" {
+      compartment_id = "${var.compartment_id}"
+      vcn_id = "${baremetal_core_subnet.private.vcn_id}"
+      ` + filters + `
+    }`
+}
+
+func (s *CoreSubnetLookupDatasourceTestSuite) TestLookupSubnet() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.lookup(`
+				  filter {
+				    name = "display_name"
+				    values = ["private"]
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "baremetal_core_subnet.private", "id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "cidr_block", "10.0.2.0/24"),
+					resource.TestCheckResourceAttr(s.ResourceName, "security_list_ids.#", "1"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestCoreSubnetLookupDatasourceTestSuite(t *testing.T) {
+	suite.Run(t, new(CoreSubnetLookupDatasourceTestSuite))
+}
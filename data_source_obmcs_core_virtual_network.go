@@ -29,6 +29,7 @@ func VirtualNetworkDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"virtual_networks": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -39,6 +40,9 @@ func VirtualNetworkDatasource() *schema.Resource {
 }
 
 func readVirtualNetworks(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &VirtualNetworkDatasourceCrud{}
 	sync.D = d
@@ -82,9 +86,9 @@ func (s *VirtualNetworkDatasourceCrud) SetData() {
 		// Important, if you don't have an ID, make one up for your datasource
 		// or things will end in tears
 		s.D.SetId(time.Now().UTC().String())
-		resources := []map[string]string{}
+		resources := []map[string]interface{}{}
 		for _, v := range s.Res.VirtualNetworks {
-			res := map[string]string{
+			res := map[string]interface{}{
 				"cidr_block":               v.CidrBlock,
 				"compartment_id":           v.CompartmentID,
 				"default_route_table_id":   v.DefaultRouteTableID,
@@ -96,6 +100,7 @@ func (s *VirtualNetworkDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("virtual_networks", resources)
 	}
 	return
@@ -84,6 +84,7 @@ func DatasourceCoreVnicAttachments() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"vnic_attachments": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -94,6 +95,9 @@ func DatasourceCoreVnicAttachments() *schema.Resource {
 }
 
 func readVnicAttachments(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &VnicAttachmentDatasourceCrud{}
 	reader.D = d
@@ -146,10 +150,10 @@ func (r *VnicAttachmentDatasourceCrud) SetData() {
 
 	if r.Res != nil {
 		r.D.SetId(time.Now().UTC().String())
-		attachments := []map[string]string{}
+		attachments := []map[string]interface{}{}
 
 		for _, att := range r.Res.Attachments {
-			attachment := map[string]string{}
+			attachment := map[string]interface{}{}
 			attachment["id"] = att.ID
 			attachment["display_name"] = att.DisplayName
 			attachment["availability_domain"] = att.AvailabilityDomain
@@ -162,6 +166,7 @@ func (r *VnicAttachmentDatasourceCrud) SetData() {
 			attachments = append(attachments, attachment)
 		}
 
+		attachments = options.ApplyFilters(r.D, attachments)
 		r.D.Set("vnic_attachments", attachments)
 
 	}
@@ -33,6 +33,7 @@ func VolumeDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"volumes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func VolumeDatasource() *schema.Resource {
 }
 
 func readVolumes(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &VolumeDatasourceCrud{}
 	sync.D = d
@@ -100,6 +104,7 @@ func (s *VolumeDatasourceCrud) SetData() {
 			}
 			volumes = append(volumes, vol)
 		}
+		volumes = options.ApplyFilters(s.D, volumes)
 		s.D.Set("volumes", volumes)
 	}
 	return
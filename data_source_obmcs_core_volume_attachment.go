@@ -41,6 +41,7 @@ func VolumeAttachmentDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"volume_attachments": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -51,6 +52,9 @@ func VolumeAttachmentDatasource() *schema.Resource {
 }
 
 func readVolumeAttachments(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &VolumeAttachmentDatasourceCrud{}
 	sync.D = d
@@ -103,21 +107,23 @@ func (s *VolumeAttachmentDatasourceCrud) SetData() {
 		// Important, if you don't have an ID, make one up for your datasource
 		// or things will end in tears
 		s.D.SetId(time.Now().UTC().String())
-		resources := []map[string]string{}
+		resources := []map[string]interface{}{}
 		for _, v := range s.Res.VolumeAttachments {
-			res := map[string]string{
+			res := map[string]interface{}{
 				"attachment_type":     v.AttachmentType,
 				"availability_domain": v.AvailabilityDomain,
 				"compartment_id":      v.CompartmentID,
 				"display_name":        v.DisplayName,
 				"id":                  v.ID,
 				"instance_id":         v.InstanceID,
+				"is_read_only":        v.IsReadOnly,
 				"state":               v.State,
 				"time_created":        v.TimeCreated.String(),
 				"volume_id":           v.VolumeID,
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("volume_attachments", resources)
 	}
 	return
@@ -25,6 +25,14 @@ func VolumeBackupDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"limit": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -33,6 +41,7 @@ func VolumeBackupDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"volume_backups": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +52,9 @@ func VolumeBackupDatasource() *schema.Resource {
 }
 
 func readVolumeBackups(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &VolumeBackupDatasourceCrud{}
 	sync.D = d
@@ -56,6 +68,33 @@ type VolumeBackupDatasourceCrud struct {
 }
 
 func (s *VolumeBackupDatasourceCrud) Get() (e error) {
+	if e = s.list(); e != nil {
+		return
+	}
+
+	// Only an AVAILABLE backup can be restored from. The newest is picked
+	// from the backups that match the filters.
+	if s.D.Get("most_recent").(bool) {
+		var newest *baremetal.VolumeBackup
+		for i, v := range s.Res.VolumeBackups {
+			if !options.Matches(s.D, volumeBackupMap(v)) {
+				continue
+			}
+			if v.State == baremetal.ResourceAvailable && (newest == nil || v.TimeCreated.After(newest.TimeCreated.Time)) {
+				newest = &s.Res.VolumeBackups[i]
+			}
+		}
+		s.Res.VolumeBackups = []baremetal.VolumeBackup{}
+		if newest != nil {
+			s.Res.VolumeBackups = append(s.Res.VolumeBackups, *newest)
+		}
+	}
+
+	return
+}
+
+// list lists the backups with the data source's volume_id and display_name.
+func (s *VolumeBackupDatasourceCrud) list() (e error) {
 	compartmentID := s.D.Get("compartment_id").(string)
 
 	opts := &baremetal.ListBackupsOptions{}
@@ -80,6 +119,19 @@ func (s *VolumeBackupDatasourceCrud) Get() (e error) {
 			break
 		}
 	}
+	if e != nil {
+		return
+	}
+
+	if displayName, ok := s.D.GetOk("display_name"); ok {
+		matching := []baremetal.VolumeBackup{}
+		for _, v := range s.Res.VolumeBackups {
+			if v.DisplayName == displayName.(string) {
+				matching = append(matching, v)
+			}
+		}
+		s.Res.VolumeBackups = matching
+	}
 
 	return
 }
@@ -89,19 +141,9 @@ func (s *VolumeBackupDatasourceCrud) SetData() {
 		s.D.SetId(time.Now().UTC().String())
 		volumes := []map[string]interface{}{}
 		for _, v := range s.Res.VolumeBackups {
-			vol := map[string]interface{}{
-				"compartment_id":        v.CompartmentID,
-				"display_name":          v.DisplayName,
-				"id":                    v.ID,
-				"state":                 v.State,
-				"size_in_mbs":           v.SizeInMBs,
-				"time_created":          v.TimeCreated.String(),
-				"time_request_received": v.TimeRequestReceived.String(),
-				"unique_size_in_mbs":    v.UniqueSizeInMBs,
-				"volume_id":             v.VolumeID,
-			}
-			volumes = append(volumes, vol)
+			volumes = append(volumes, volumeBackupMap(v))
 		}
+		volumes = options.ApplyFilters(s.D, volumes)
 		s.D.Set("volume_backups", volumes)
 	}
 	return
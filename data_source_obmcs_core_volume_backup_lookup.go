@@ -0,0 +1,102 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// VolumeBackupLookupDatasource finds the one volume backup that matches its
+// arguments and filters.
+func VolumeBackupLookupDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readVolumeBackupLookup,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"filter": options.FilterSchema(),
+			// Computed
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size_in_mbs": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_request_received": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"unique_size_in_mbs": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readVolumeBackupLookup(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &VolumeBackupLookupDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type VolumeBackupLookupDatasourceCrud struct {
+	VolumeBackupDatasourceCrud
+	Item map[string]interface{}
+}
+
+func (s *VolumeBackupLookupDatasourceCrud) Get() (e error) {
+	if e = s.list(); e != nil {
+		return
+	}
+
+	// Like the volume backups data source, most_recent only picks from the
+	// backups that can be restored from.
+	mostRecent := s.D.Get("most_recent").(bool)
+	backups := []map[string]interface{}{}
+	created := []baremetal.Time{}
+	for _, v := range s.Res.VolumeBackups {
+		if !mostRecent || v.State == baremetal.ResourceAvailable {
+			backups = append(backups, volumeBackupMap(v))
+			created = append(created, v.TimeCreated)
+		}
+	}
+	s.Item, e = options.SelectOne(s.D, "volume backup", backups, created, mostRecent)
+	return
+}
+
+func (s *VolumeBackupLookupDatasourceCrud) SetData() {
+	options.SetItem(s.D, s.Item)
+}
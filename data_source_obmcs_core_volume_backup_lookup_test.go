@@ -0,0 +1,108 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CoreVolumeBackupLookupDatasourceTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *CoreVolumeBackupLookupDatasourceTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+	s.Provider = Provider(func(d *schema.ResourceData) (interface{}, error) {
+		return s.Client, nil
+	})
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+	s.Config = `
+data "baremetal_identity_availability_domains" "ADs" {
+	compartment_id = "${var.compartment_id}"
+}
+resource "baremetal_core_volume" "t" {
+	availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	compartment_id = "${var.compartment_id}"
+	display_name = "display_name"
+	size_in_mbs = 262144
+}
+resource "baremetal_core_volume_backup" "first" {
+	volume_id = "${baremetal_core_volume.t.id}"
+	display_name = "nightly"
+}
+`
+	s.Config += testProviderConfig()
+	s.ResourceName = "data.baremetal_core_volume_backup.t"
+}
+
+// lookup adds a second backup, and looks up one of the two.
+func (s *CoreVolumeBackupLookupDatasourceTestSuite) lookup(args string) string {
+	return s.Config + `
+    resource "baremetal_core_volume_backup" "second" {
+      volume_id = "${baremetal_core_volume_backup.first.volume_id}"
+      display_name = "nightly"
+    }
+    data "baremetal_core_volume_backup" "t" {
+      compartment_id = "${var.compartment_id}"
+      volume_id = "${baremetal_core_volume_backup.second.volume_id}"
+      display_name = "nightly"
+      ` + args + `
+    }`
+}
+
+func (s *CoreVolumeBackupLookupDatasourceTestSuite) TestLookupVolumeBackup() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+			},
+			// The API's times are in seconds, so wait for the second backup
+			// to be newer.
+			{
+				PreConfig: func() { time.Sleep(time.Second) },
+				Config:    s.lookup("most_recent = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "baremetal_core_volume_backup.second", "id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", "AVAILABLE"),
+				),
+			},
+			{
+				Config:      s.lookup(""),
+				ExpectError: regexp.MustCompile("2 volume backups match the arguments and filters"),
+			},
+			{
+				Config: s.lookup(`
+				  filter {
+				    name = "id"
+				    values = ["${baremetal_core_volume_backup.first.id}"]
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "baremetal_core_volume_backup.first", "id"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestCoreVolumeBackupLookupDatasourceTestSuite(t *testing.T) {
+	suite.Run(t, new(CoreVolumeBackupLookupDatasourceTestSuite))
+}
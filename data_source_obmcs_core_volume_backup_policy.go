@@ -0,0 +1,127 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"math"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// VolumeBackupPolicyDatasource works out which backups of a volume a backup
+// policy keeps, which have expired and whether a backup is due.
+func VolumeBackupPolicyDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readVolumeBackupPolicyDatasource,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSchedule,
+			},
+			"retention_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+			},
+			"display_name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "policy-backup",
+			},
+			"filter": options.FilterSchema(),
+			"backup_due": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"next_backup_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"retained_backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     VolumeBackupResource(),
+			},
+			"expired_backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     VolumeBackupResource(),
+			},
+		},
+	}
+}
+
+func readVolumeBackupPolicyDatasource(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &VolumeBackupPolicyDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type VolumeBackupPolicyDatasourceCrud struct {
+	crud.BaseCrud
+	Res []baremetal.VolumeBackup
+}
+
+func (s *VolumeBackupPolicyDatasourceCrud) Get() (e error) {
+	var backups []baremetal.VolumeBackup
+	backups, e = policyBackups(
+		s.Client,
+		s.D.Get("compartment_id").(string),
+		s.D.Get("volume_id").(string),
+		s.D.Get("display_name_prefix").(string),
+	)
+	if e != nil {
+		return
+	}
+
+	// Only the backups the filters keep count towards the policy.
+	s.Res = []baremetal.VolumeBackup{}
+	for _, b := range backups {
+		if options.Matches(s.D, volumeBackupMap(b)) {
+			s.Res = append(s.Res, b)
+		}
+	}
+	return
+}
+
+func (s *VolumeBackupPolicyDatasourceCrud) SetData() {
+	s.D.SetId(time.Now().UTC().String())
+
+	retained, expired := expireBackups(s.Res, s.D.Get("retention_count").(int))
+	retainedMaps := []map[string]interface{}{}
+	for _, b := range retained {
+		retainedMaps = append(retainedMaps, volumeBackupMap(b))
+	}
+	s.D.Set("retained_backups", retainedMaps)
+	expiredMaps := []map[string]interface{}{}
+	for _, b := range expired {
+		expiredMaps = append(expiredMaps, volumeBackupMap(b))
+	}
+	s.D.Set("expired_backups", expiredMaps)
+
+	schedule, _ := time.ParseDuration(s.D.Get("schedule").(string))
+	now := time.Now()
+	next := nextBackupTime(s.Res, schedule, now)
+	s.D.Set("next_backup_time", next.UTC().Format(time.RFC3339))
+	s.D.Set("backup_due", !now.Before(next))
+}
@@ -11,7 +11,11 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
+	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
 type ResourceCoreVolumeBackupsTestSuite struct {
@@ -99,6 +103,46 @@ func (s *ResourceCoreVolumeBackupsTestSuite) TestReadVolumeBackups() {
 	)
 }
 
+// most_recent picks the newest of the backups that match the filters, not
+// the newest backup.
+func TestReadMostRecentFilteredVolumeBackup(t *testing.T) {
+	older := baremetal.VolumeBackup{
+		ID:          "older",
+		DisplayName: "nightly",
+		State:       baremetal.ResourceAvailable,
+		TimeCreated: baremetal.Time{Time: time.Now().Add(-time.Hour)},
+	}
+	newer := older
+	newer.ID = "newer"
+	newer.DisplayName = "manual"
+	newer.TimeCreated = baremetal.Time{Time: time.Now()}
+
+	client := &mocks.BareMetalClient{}
+	client.On("ListVolumeBackups", "compartment", mock.Anything).Return(&baremetal.ListVolumeBackups{
+		VolumeBackups: []baremetal.VolumeBackup{older, newer},
+	}, nil)
+
+	d := VolumeBackupDatasource().Data(nil)
+	d.Set("compartment_id", "compartment")
+	d.Set("most_recent", true)
+	d.Set("filter", []interface{}{
+		map[string]interface{}{"name": "display_name", "values": []interface{}{"nightly"}},
+	})
+	sync := &VolumeBackupDatasourceCrud{}
+	sync.D = d
+	sync.Client = client
+
+	if e := crud.ReadResource(sync); e != nil {
+		t.Fatal(e)
+	}
+	if n := d.Get("volume_backups.#").(int); n != 1 {
+		t.Fatalf("Expected one volume backup, got %d", n)
+	}
+	if id := d.Get("volume_backups.0.id").(string); id != "older" {
+		t.Errorf("Expected the newest nightly backup, got %q", id)
+	}
+}
+
 func TestResourceCoreVolumeBackupsTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreVolumeBackupsTestSuite))
 }
@@ -33,6 +33,7 @@ func DatabasesDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"databases": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func DatabasesDatasource() *schema.Resource {
 }
 
 func readDatabases(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DatabasesDatasourceCrud{}
 	sync.D = d
@@ -99,6 +103,7 @@ func (s *DatabasesDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("databases", resources)
 	}
 	return
@@ -54,7 +54,7 @@ func (s *DatabaseDatabasesTestSuite) TestReadDatabases() {
 				}
 			        data "baremetal_database_databases" "t" {
 				      compartment_id = "${var.compartment_id}"
-				      db_home_id = "${data.baremetal_database_db_homes.t.id}"
+				      db_home_id = "${data.baremetal_database_db_homes.t.db_homes.0.id}"
 				}
 				data "baremetal_database_database" "t" {
 				      database_id = "${data.baremetal_database_databases.t.databases.0.id}"
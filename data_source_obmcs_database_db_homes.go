@@ -33,6 +33,7 @@ func DBHomesDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"db_homes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func DBHomesDatasource() *schema.Resource {
 }
 
 func readDBHomes(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DBHomesDatasourceCrud{}
 	sync.D = d
@@ -98,6 +102,7 @@ func (s *DBHomesDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("db_homes", resources)
 	}
 	return
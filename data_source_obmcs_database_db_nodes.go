@@ -33,6 +33,7 @@ func DBNodesDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"db_nodes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,6 +44,9 @@ func DBNodesDatasource() *schema.Resource {
 }
 
 func readDBNodes(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DBNodesDatasourceCrud{}
 	sync.D = d
@@ -98,6 +102,7 @@ func (s *DBNodesDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("db_nodes", resources)
 	}
 	return
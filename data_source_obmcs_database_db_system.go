@@ -29,6 +29,7 @@ func DBSystemDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"db_systems": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -39,6 +40,9 @@ func DBSystemDatasource() *schema.Resource {
 }
 
 func readDBSystems(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &DBSystemDatasourceCrud{}
 	sync.D = d
@@ -111,6 +115,7 @@ func (s *DBSystemDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("db_systems", resources)
 	}
 	return
@@ -17,6 +17,7 @@ func DBSystemShapeDatasource() *schema.Resource {
 	return &schema.Resource{
 		Read: readDBSystemShapes,
 		Schema: map[string]*schema.Schema{
+			"filter": options.FilterSchema(),
 			"db_system_shapes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -58,6 +59,9 @@ func DBSystemShapeDatasource() *schema.Resource {
 }
 
 func readDBSystemShapes(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &DBSystemShapeDatasourceCrud{}
 	reader.D = d
@@ -112,6 +116,7 @@ func (s *DBSystemShapeDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("db_system_shapes", resources)
 	}
 	return
@@ -21,6 +21,7 @@ func DBVersionDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"db_versions": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -46,6 +47,9 @@ func DBVersionDatasource() *schema.Resource {
 }
 
 func readDBVersions(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &DBVersionDatasourceCrud{}
 	reader.D = d
@@ -95,6 +99,7 @@ func (s *DBVersionDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("db_versions", resources)
 	}
 	return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func APIKeyDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func APIKeyDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"api_keys": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func APIKeyDatasource() *schema.Resource {
 }
 
 func readAPIKeys(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &APIKeyDatasourceCrud{}
 	sync.D = d
@@ -63,6 +68,7 @@ func (s *APIKeyDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("api_keys", resources)
 	}
 	return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func AvailabilityDomainDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func AvailabilityDomainDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"availability_domains": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -42,6 +44,9 @@ func AvailabilityDomainDatasource() *schema.Resource {
 }
 
 func readAvailabilityDomains(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &AvailabilityDomainDatasourceCrud{}
 	sync.D = d
@@ -71,6 +76,7 @@ func (s *AvailabilityDomainDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("availability_domains", resources)
 	}
 	return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func CompartmentDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func CompartmentDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"compartments": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func CompartmentDatasource() *schema.Resource {
 }
 
 func readCompartments(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &CompartmentDatasourceCrud{}
 	sync.D = d
@@ -52,17 +57,9 @@ func (s *CompartmentDatasourceCrud) SetData() {
 		s.D.SetId(time.Now().UTC().String())
 		resources := []map[string]interface{}{}
 		for _, v := range s.Res.Compartments {
-			res := map[string]interface{}{
-				"compartment_id": v.CompartmentID,
-				"description":    v.Description,
-				"id":             v.ID,
-				"inactive_state": v.InactiveStatus,
-				"name":           v.Name,
-				"state":          v.State,
-				"time_created":   v.TimeCreated.String(),
-			}
-			resources = append(resources, res)
+			resources = append(resources, compartmentMap(v))
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("compartments", resources); err != nil {
 			panic(err)
 		}
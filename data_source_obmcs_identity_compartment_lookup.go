@@ -0,0 +1,79 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// CompartmentLookupDatasource finds the one compartment of the tenancy that
+// matches its filters.
+func CompartmentLookupDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readCompartmentLookup,
+		Schema: map[string]*schema.Schema{
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter": options.FilterSchema(),
+			// Computed
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"inactive_state": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func readCompartmentLookup(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &CompartmentLookupDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type CompartmentLookupDatasourceCrud struct {
+	CompartmentDatasourceCrud
+	Item map[string]interface{}
+}
+
+func (s *CompartmentLookupDatasourceCrud) Get() (e error) {
+	if e = s.CompartmentDatasourceCrud.Get(); e != nil {
+		return
+	}
+
+	compartments := []map[string]interface{}{}
+	for _, v := range s.Res.Compartments {
+		compartments = append(compartments, compartmentMap(v))
+	}
+	s.Item, e = options.SelectOne(s.D, "compartment", compartments, nil, false)
+	return
+}
+
+func (s *CompartmentLookupDatasourceCrud) SetData() {
+	options.SetItem(s.D, s.Item)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IdentityCompartmentLookupDatasourceTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Config       string
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *IdentityCompartmentLookupDatasourceTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+	s.Provider = Provider(func(d *schema.ResourceData) (interface{}, error) {
+		return s.Client, nil
+	})
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+	s.Config = `
+    resource "baremetal_identity_compartment" "t" {
+      name = "-tf-compartment-lookup"
+      description = "lookup"
+    }
+  ` + testProviderConfig()
+	s.ResourceName = "data.baremetal_identity_compartment.t"
+}
+
+func (s *IdentityCompartmentLookupDatasourceTestSuite) TestLookupCompartment() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + `
+				    data "baremetal_identity_compartment" "t" {
+				      compartment_id = "${baremetal_identity_compartment.t.compartment_id}"
+				      filter {
+				        name = "name"
+				        values = ["${baremetal_identity_compartment.t.name}"]
+				      }
+				    }`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "id", "baremetal_identity_compartment.t", "id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "description", "lookup"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", "ACTIVE"),
+				),
+			},
+		},
+	},
+	)
+}
+
+func TestIdentityCompartmentLookupDatasourceTestSuite(t *testing.T) {
+	suite.Run(t, new(IdentityCompartmentLookupDatasourceTestSuite))
+}
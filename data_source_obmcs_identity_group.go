@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func GroupDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func GroupDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"groups": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func GroupDatasource() *schema.Resource {
 }
 
 func readGroups(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &GroupDatasourceCrud{}
 	sync.D = d
@@ -63,6 +68,7 @@ func (s *GroupDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("groups", resources); err != nil {
 			panic(err)
 		}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func IdentityPolicyDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func IdentityPolicyDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"policies": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func IdentityPolicyDatasource() *schema.Resource {
 }
 
 func readIdentityPolicies(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &IdentityPolicyDatasourceCrud{}
 	sync.D = d
@@ -67,6 +72,7 @@ func (s *IdentityPolicyDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("policies", resources); err != nil {
 			panic(err)
 		}
@@ -58,7 +58,7 @@ func (s *ResourceIdentityPoliciesTestSuite) SetupTest() {
 	    depends_on = ["baremetal_identity_group.t"]
 	  }
 	data "baremetal_identity_policies" "p" {
-		compartment_id = "${data.baremetal_identity_compartments.t.compartments.0.id}"
+		compartment_id = "${baremetal_identity_policy.p.compartment_id}"
 	}
 	  `
 	s.Config += testProviderConfig()
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func SwiftPasswordDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func SwiftPasswordDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"passwords": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func SwiftPasswordDatasource() *schema.Resource {
 }
 
 func readSwiftPasswords(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &SwiftPasswordDatasourceCrud{}
 	sync.D = d
@@ -65,6 +70,7 @@ func (s *SwiftPasswordDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("passwords", resources); err != nil {
 			panic(err)
 		}
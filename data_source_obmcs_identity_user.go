@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func UserDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func UserDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"users": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func UserDatasource() *schema.Resource {
 }
 
 func readUsers(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &UserDatasourceCrud{}
 	sync.D = d
@@ -63,6 +68,7 @@ func (s *UserDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("users", resources); err != nil {
 			panic(err)
 		}
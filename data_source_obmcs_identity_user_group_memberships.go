@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func UserGroupMembershipDatasource() *schema.Resource {
@@ -28,6 +29,7 @@ func UserGroupMembershipDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"memberships": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -38,6 +40,9 @@ func UserGroupMembershipDatasource() *schema.Resource {
 }
 
 func readUserGroupMemberships(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &UserGroupMembershipDatasourceCrud{}
 	sync.D = d
@@ -79,6 +84,7 @@ func (s *UserGroupMembershipDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		if err := s.D.Set("memberships", resources); err != nil {
 			panic(err)
 		}
@@ -12,6 +12,9 @@ import (
 )
 
 func TestLoadBalancerBackendsetsDatasource(t *testing.T) {
+	if IsAccTest() {
+		t.Skip()
+	}
 	client := GetTestProvider()
 	providers := map[string]terraform.ResourceProvider{
 		"baremetal": Provider(func(d *schema.ResourceData) (interface{}, error) {
@@ -0,0 +1,75 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestLoadBalancerWorkRequestsDatasource(t *testing.T) {
+	if IsAccTest() {
+		t.Skip()
+	}
+	client := GetTestProvider()
+	providers := map[string]terraform.ResourceProvider{
+		"baremetal": Provider(func(d *schema.ResourceData) (interface{}, error) {
+			return client, nil
+		}),
+	}
+	resourceName := "data.baremetal_load_balancer_work_requests.t"
+	config := `
+data "baremetal_load_balancer_work_requests" "t" {
+  load_balancer_id = "ocid1.loadbalancer.stub_id"
+}
+`
+	config += testProviderConfig()
+
+	loadbalancerID := "ocid1.loadbalancer.stub_id"
+	list := &baremetal.ListWorkRequests{
+		WorkRequests: []baremetal.WorkRequest{
+			{
+				ID:             "ocid1.loadbalancerworkrequest.stub_id1",
+				LoadBalancerID: loadbalancerID,
+				Type:           "CreateLoadBalancer",
+				State:          baremetal.WorkRequestSucceeded,
+			},
+			{
+				ID:             "ocid1.loadbalancerworkrequest.stub_id2",
+				LoadBalancerID: loadbalancerID,
+				Type:           "CreateListener",
+				State:          baremetal.WorkRequestFailed,
+				ErrorDetails: []baremetal.WorkRequestError{
+					{ErrorCode: "BAD_INPUT", Message: "Invalid SSL configuration"},
+				},
+			},
+		},
+	}
+	client.On(
+		"ListWorkRequests",
+		loadbalancerID,
+		&baremetal.ListLoadBalancerPolicyOptions{},
+	).Return(list, nil)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreventPostDestroyRefresh: true,
+		Providers:                 providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "load_balancer_id", loadbalancerID),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.0.type", "CreateLoadBalancer"),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.1.state", baremetal.WorkRequestFailed),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.1.error_details.0.error_code", "BAD_INPUT"),
+					resource.TestCheckResourceAttr(resourceName, "work_requests.1.error_details.0.message", "Invalid SSL configuration"),
+				),
+			},
+		},
+	})
+}
@@ -13,6 +13,9 @@ import (
 )
 
 func TestLoadBalancersDatasource(t *testing.T) {
+	if IsAccTest() {
+		t.Skip()
+	}
 	client := GetTestProvider()
 	provider := Provider(func(d *schema.ResourceData) (interface{}, error) {
 		return client, nil
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func BackendDatasource() *schema.Resource {
@@ -24,6 +25,7 @@ func BackendDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"backends": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -34,6 +36,9 @@ func BackendDatasource() *schema.Resource {
 }
 
 func readBackends(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &BackendDatasourceCrud{}
 	sync.D = d
@@ -68,6 +73,7 @@ func (s *BackendDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("backends", resources)
 	}
 	return
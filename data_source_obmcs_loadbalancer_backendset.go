@@ -11,6 +11,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func BackendSetDatasource() *schema.Resource {
@@ -21,6 +22,7 @@ func BackendSetDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"backendsets": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -31,6 +33,9 @@ func BackendSetDatasource() *schema.Resource {
 }
 
 func readBackendSets(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &BackendSetDatasourceCrud{}
 	sync.D = d
@@ -51,7 +56,8 @@ func (s *BackendSetDatasourceCrud) Get() (e error) {
 
 func (s *BackendSetDatasourceCrud) SetData() {
 	if s.Res == nil {
-		panic("LoadBalancer Backend Resource is nil, cannot SetData")
+		// Get found nothing and voided the data source.
+		return
 	}
 	s.D.SetId(time.Now().UTC().String())
 	resources := []map[string]interface{}{}
@@ -99,6 +105,7 @@ func (s *BackendSetDatasourceCrud) SetData() {
 		}
 		resources = append(resources, res)
 	}
+	resources = options.ApplyFilters(s.D, resources)
 	err := s.D.Set("backendsets", resources)
 	if err != nil {
 		log.Printf("[ERROR] Failed to set load_balancers: %v", err)
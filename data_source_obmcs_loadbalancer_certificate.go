@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func CertificateDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func CertificateDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"certificates": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -30,6 +32,9 @@ func CertificateDatasource() *schema.Resource {
 }
 
 func readCertificate(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &CertificateDatasourceCrud{}
 	sync.D = d
@@ -60,6 +65,7 @@ func (s *CertificateDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("certificates", resources)
 	}
 	return
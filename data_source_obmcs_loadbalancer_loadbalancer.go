@@ -11,6 +11,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func LoadBalancerDatasource() *schema.Resource {
@@ -21,6 +22,7 @@ func LoadBalancerDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"load_balancers": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -31,6 +33,9 @@ func LoadBalancerDatasource() *schema.Resource {
 }
 
 func readLoadBalancers(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &LoadBalancerDatasourceCrud{}
 	sync.D = d
@@ -51,7 +56,8 @@ func (s *LoadBalancerDatasourceCrud) Get() (e error) {
 
 func (s *LoadBalancerDatasourceCrud) SetData() {
 	if s.Res == nil {
-		panic("LoadBalancer Resource is nil, cannot SetData")
+		// Get found nothing and voided the data source.
+		return
 	}
 	s.D.SetId(time.Now().UTC().String())
 
@@ -73,6 +79,7 @@ func (s *LoadBalancerDatasourceCrud) SetData() {
 		}
 
 	}
+	resources = options.ApplyFilters(s.D, resources)
 	err := s.D.Set("load_balancers", resources)
 	if err != nil {
 		log.Printf("[ERROR] Failed to set load_balancers: %v", err)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func LoadBalancerPolicyDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func LoadBalancerPolicyDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"policies": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -37,6 +39,9 @@ func LoadBalancerPolicyDatasource() *schema.Resource {
 }
 
 func readPolicies(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &PoliciesDatasourceCrud{}
 	sync.D = d
@@ -67,6 +72,7 @@ func (s *PoliciesDatasourceCrud) SetData() {
 			resources = append(resources, res)
 
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("policies", resources)
 	}
 	return
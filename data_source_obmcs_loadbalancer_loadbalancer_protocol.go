@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func ProtocolDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func ProtocolDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"protocols": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -37,6 +39,9 @@ func ProtocolDatasource() *schema.Resource {
 }
 
 func readProtocols(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &ProtocolDatasourceCrud{}
 	sync.D = d
@@ -67,6 +72,7 @@ func (s *ProtocolDatasourceCrud) SetData() {
 			resources = append(resources, res)
 
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("protocols", resources)
 	}
 	return
@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func LoadBalancerShapeDatasource() *schema.Resource {
@@ -20,6 +21,7 @@ func LoadBalancerShapeDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"shapes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -37,6 +39,9 @@ func LoadBalancerShapeDatasource() *schema.Resource {
 }
 
 func readLoadBalancerShapes(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	sync := &LoadBalancerShapeDatasourceCrud{}
 	sync.D = d
@@ -67,6 +72,7 @@ func (s *LoadBalancerShapeDatasourceCrud) SetData() {
 			resources = append(resources, res)
 
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("shapes", resources)
 	}
 	return
@@ -0,0 +1,151 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+func LoadBalancerWorkRequestDatasource() *schema.Resource {
+	return &schema.Resource{
+		Read: readLoadBalancerWorkRequests,
+		Schema: map[string]*schema.Schema{
+			"load_balancer_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"filter": options.FilterSchema(),
+			"work_requests": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     LoadBalancerWorkRequestResource(),
+			},
+		},
+	}
+}
+
+func LoadBalancerWorkRequestResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"load_balancer_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_accepted": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_finished": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"error_details": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"error_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func readLoadBalancerWorkRequests(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
+	sync := &LoadBalancerWorkRequestDatasourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+type LoadBalancerWorkRequestDatasourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.ListWorkRequests
+}
+
+func (s *LoadBalancerWorkRequestDatasourceCrud) Get() (e error) {
+	lbID := s.D.Get("load_balancer_id").(string)
+	opts := &baremetal.ListLoadBalancerPolicyOptions{}
+
+	s.Res = &baremetal.ListWorkRequests{WorkRequests: []baremetal.WorkRequest{}}
+
+	for {
+		var list *baremetal.ListWorkRequests
+		if list, e = s.Client.ListWorkRequests(lbID, opts); e != nil {
+			break
+		}
+
+		s.Res.WorkRequests = append(s.Res.WorkRequests, list.WorkRequests...)
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+	return
+}
+
+func (s *LoadBalancerWorkRequestDatasourceCrud) SetData() {
+	if s.Res != nil {
+		s.D.SetId(time.Now().UTC().String())
+		resources := []map[string]interface{}{}
+		for _, v := range s.Res.WorkRequests {
+			errorDetails := []map[string]interface{}{}
+			for _, detail := range v.ErrorDetails {
+				errorDetails = append(errorDetails, map[string]interface{}{
+					"error_code": detail.ErrorCode,
+					"message":    detail.Message,
+				})
+			}
+			res := map[string]interface{}{
+				"id":               v.ID,
+				"load_balancer_id": v.LoadBalancerID,
+				"type":             v.Type,
+				"state":            v.State,
+				"message":          v.Message,
+				"time_accepted":    v.TimeAccepted.String(),
+				"error_details":    errorDetails,
+			}
+			if !v.TimeFinished.IsZero() {
+				res["time_finished"] = v.TimeFinished.String()
+			}
+			resources = append(resources, res)
+		}
+		resources = options.ApplyFilters(s.D, resources)
+		s.D.Set("work_requests", resources)
+	}
+	return
+}
@@ -25,6 +25,7 @@ func BucketSummaryDatasource() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"filter": options.FilterSchema(),
 			"bucket_summaries": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -70,6 +71,9 @@ func BucketSummaryDatasource() *schema.Resource {
 }
 
 func readBucketSummaries(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &BucketSummaryDatasourceCrud{}
 	reader.D = d
@@ -126,6 +130,7 @@ func (s *BucketSummaryDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("bucket_summaries", resources)
 	}
 	return
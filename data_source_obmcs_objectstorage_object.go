@@ -10,6 +10,7 @@ import (
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func ObjectDatasource() *schema.Resource {
@@ -40,6 +41,7 @@ func ObjectDatasource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": options.FilterSchema(),
 			"objects": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -73,6 +75,9 @@ func resourceObjectSummary() *schema.Resource {
 }
 
 func readObjects(d *schema.ResourceData, m interface{}) (e error) {
+	if e = options.CheckFilters(d); e != nil {
+		return
+	}
 	client := m.(client.BareMetalClient)
 	reader := &ObjectDatasourceCrud{}
 	reader.D = d
@@ -142,6 +147,7 @@ func (s *ObjectDatasourceCrud) SetData() {
 			}
 			resources = append(resources, res)
 		}
+		resources = options.ApplyFilters(s.D, resources)
 		s.D.Set("objects", resources)
 	}
 	return
@@ -72,7 +72,7 @@ func (s *ObjectHeadDatasourceCrud) SetData() {
 	// or things will end in tears
 	s.D.SetId(time.Now().UTC().String())
 	s.D.Set("metadata", s.Res.Metadata)
-	s.D.Set("content-length", string(s.Res.ContentLength))
+	s.D.Set("content-length", int(s.Res.ContentLength))
 	s.D.Set("content-type", s.Res.ContentType)
 	return
 }
@@ -0,0 +1,312 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+var (
+	provisioned = []string{"PROVISIONING", "AVAILABLE"}
+	terminated  = []string{"TERMINATING", "TERMINATED"}
+	attached    = []string{"ATTACHING", "ATTACHED"}
+	detached    = []string{"DETACHING", "DETACHED"}
+)
+
+// Names of the seeded availability domains, shapes and images.
+var (
+	AvailabilityDomains = []string{"kIdk:PHX-AD-1", "kIdk:PHX-AD-2", "kIdk:PHX-AD-3"}
+	Shapes              = []string{"BM.Standard1.36", "BM.HighIO1.36", "BM.DenseIO1.36", "VM.Standard1.1", "VM.Standard1.2"}
+	Images              = []string{"Oracle-Linux-7.3-2017.05.23-0", "Canonical-Ubuntu-16.04-2017.05.18-0"}
+)
+
+func newCollections(cs ...*collection) map[string]*collection {
+	m := make(map[string]*collection, len(cs))
+	for _, c := range cs {
+		m[c.name] = c
+	}
+	return m
+}
+
+func coreCollections() map[string]*collection {
+	shapes := &collection{name: "shapes", idField: "shape", readOnly: true}
+	for _, name := range Shapes {
+		shapes.add(newRecord(map[string]interface{}{"shape": name}))
+	}
+
+	images := &collection{
+		name:     "images",
+		kind:     "image",
+		created:  provisioned,
+		deleted:  []string{"DELETED"},
+		onCreate: createImage,
+	}
+	for i, name := range Images {
+		images.add(newRecord(map[string]interface{}{
+			"id":                     fmt.Sprintf("ocid1.image.oc1.phx.platformimage%d", i),
+			"displayName":            name,
+			"operatingSystem":        []string{"Oracle Linux", "Canonical Ubuntu"}[i],
+			"operatingSystemVersion": []string{"7.3", "16.04"}[i],
+			"createImageAllowed":     true,
+			"lifecycleState":         "AVAILABLE",
+			"timeCreated":            now(),
+		}))
+	}
+
+	return newCollections(
+		images,
+		shapes,
+		&collection{name: "cpes", kind: "cpe"},
+		&collection{name: "dhcps", kind: "dhcpoptions", created: provisioned, deleted: terminated},
+		&collection{name: "drgAttachments", kind: "drgattachment", created: attached, deleted: detached, onCreate: attachDrg},
+		&collection{name: "drgs", kind: "drg", created: provisioned, deleted: terminated},
+		&collection{
+			name:     "instanceConsoleHistories",
+			kind:     "consolehistory",
+			created:  []string{"REQUESTED", "SUCCEEDED"},
+			onCreate: copyFromInstance,
+		},
+		&collection{
+			name:     "instances",
+			kind:     "instance",
+			created:  []string{"PROVISIONING", "RUNNING"},
+			deleted:  terminated,
+			onCreate: launchInstance,
+			onDelete: terminateInstance,
+			actions: map[string][]string{
+				"START":     {"STARTING", "RUNNING"},
+				"STOP":      {"STOPPING", "STOPPED"},
+				"RESET":     {"STOPPING", "STARTING", "RUNNING"},
+				"SOFTRESET": {"STOPPING", "STARTING", "RUNNING"},
+			},
+		},
+		&collection{name: "internetGateways", kind: "internetgateway", created: provisioned, deleted: terminated},
+		&collection{name: "ipsecConnections", kind: "ipsecconnection", created: provisioned, deleted: terminated},
+		&collection{name: "routeTables", kind: "routetable", created: provisioned, deleted: terminated},
+		&collection{name: "securityLists", kind: "securitylist", created: provisioned, deleted: terminated},
+		&collection{name: "subnets", kind: "subnet", created: provisioned, deleted: terminated, onCreate: createSubnet},
+		&collection{name: "vcns", kind: "vcn", created: provisioned, deleted: terminated, onCreate: createVCN},
+		&collection{name: "vnicAttachments", kind: "vnicattachment", readOnly: true},
+		&collection{name: "vnics", kind: "vnic", readOnly: true},
+		&collection{name: "volumeAttachments", kind: "volumeattachment", created: attached, deleted: detached, onCreate: attachVolume},
+		&collection{name: "volumeBackups", kind: "volumebackup", created: []string{"REQUEST_RECEIVED", "CREATING", "AVAILABLE"}, deleted: terminated, onCreate: createVolumeBackup},
+		&collection{name: "volumes", kind: "volume", created: provisioned, deleted: terminated, updated: provisioned, onCreate: createVolume},
+	)
+}
+
+func init() {
+	subResourceHandlers["instances/initialCredentials"] = func(s *Server, ctx *requestContext, r *record) {
+		writeJSON(ctx.w, http.StatusOK, map[string]string{
+			"username": "opc",
+			"password": randomHex(8),
+		})
+	}
+
+	subResourceHandlers["instanceConsoleHistories/data"] = func(s *Server, ctx *requestContext, r *record) {
+		ctx.w.Header().Set("opc-bytes-remaining", "0")
+		ctx.w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintf(ctx.w, "Console history of %s\n", r.str("instanceId"))
+	}
+
+	subResourceHandlers["ipsecConnections/deviceConfig"] = func(s *Server, ctx *requestContext, r *record) {
+		writeJSON(ctx.w, http.StatusOK, map[string]interface{}{
+			"id":            r.str("id"),
+			"compartmentId": r.str("compartmentId"),
+			"timeCreated":   r.str("timeCreated"),
+			"tunnels": []map[string]interface{}{
+				{"ipAddress": "129.146.0.1", "sharedSecret": randomHex(16), "timeCreated": r.str("timeCreated")},
+				{"ipAddress": "129.146.0.2", "sharedSecret": randomHex(16), "timeCreated": r.str("timeCreated")},
+			},
+		})
+	}
+
+	subResourceHandlers["ipsecConnections/deviceStatus"] = func(s *Server, ctx *requestContext, r *record) {
+		writeJSON(ctx.w, http.StatusOK, map[string]interface{}{
+			"id":            r.str("id"),
+			"compartmentId": r.str("compartmentId"),
+			"timeCreated":   r.str("timeCreated"),
+			"tunnels": []map[string]interface{}{
+				{"ipAddress": "129.146.0.1", "lifecycleState": "UP", "timeCreated": r.str("timeCreated"), "timeStateModified": now()},
+				{"ipAddress": "129.146.0.2", "lifecycleState": "UP", "timeCreated": r.str("timeCreated"), "timeStateModified": now()},
+			},
+		})
+	}
+}
+
+func (s *Server) core(name string) *collection {
+	return s.services["iaas"][name]
+}
+
+// launchInstance attaches a primary VNIC to the new instance.
+func launchInstance(s *Server, ctx *requestContext, r *record) {
+	r.fields["region"] = ctx.region
+	subnetID := ctx.body["subnetId"]
+	delete(r.fields, "subnetId")
+
+	n := len(s.core("vnics").order)
+	privateIP := fmt.Sprintf("10.0.%d.%d", n/250, n%250+2)
+	publicIP := fmt.Sprintf("129.146.%d.%d", n/250, n%250+2)
+	if details, ok := ctx.body["createVnicDetails"].(map[string]interface{}); ok {
+		if id, ok := details["subnetId"]; ok {
+			subnetID = id
+		}
+		if ip, ok := details["privateIp"].(string); ok {
+			privateIP = ip
+		}
+		if assign, ok := details["assignPublicIp"].(bool); ok && !assign {
+			publicIP = ""
+		}
+		delete(r.fields, "createVnicDetails")
+	}
+
+	vnic := newRecord(map[string]interface{}{
+		"id":                 newOCID("vnic", ctx.region),
+		"availabilityDomain": r.fields["availabilityDomain"],
+		"compartmentId":      r.fields["compartmentId"],
+		"displayName":        r.fields["displayName"],
+		"hostnameLabel":      r.fields["hostnameLabel"],
+		"lifecycleState":     "AVAILABLE",
+		"privateIp":          privateIP,
+		"publicIp":           publicIP,
+		"subnetId":           subnetID,
+		"timeCreated":        now(),
+	})
+	s.core("vnics").add(vnic)
+
+	s.core("vnicAttachments").add(newRecord(map[string]interface{}{
+		"id":                 newOCID("vnicattachment", ctx.region),
+		"availabilityDomain": r.fields["availabilityDomain"],
+		"compartmentId":      r.fields["compartmentId"],
+		"displayName":        r.fields["displayName"],
+		"instanceId":         r.fields["id"],
+		"lifecycleState":     "ATTACHED",
+		"subnetId":           subnetID,
+		"timeCreated":        now(),
+		"vnicId":             vnic.fields["id"],
+	}))
+}
+
+// terminateInstance detaches everything attached to the instance.
+func terminateInstance(s *Server, r *record) {
+	for _, name := range []string{"vnicAttachments", "volumeAttachments"} {
+		c := s.core(name)
+		for _, id := range c.order {
+			if a := c.records[id]; a.str("instanceId") == r.str("id") {
+				a.fields["lifecycleState"] = "DETACHED"
+			}
+		}
+	}
+}
+
+// createImage records the image the instance was launched from as the base
+// of the new custom image.
+func createImage(s *Server, ctx *requestContext, r *record) {
+	if inst, ok := s.core("instances").get(r.str("instanceId")); ok {
+		r.fields["baseImageId"] = inst.fields["imageId"]
+	}
+}
+
+func copyFromInstance(s *Server, ctx *requestContext, r *record) {
+	if inst, ok := s.core("instances").get(r.str("instanceId")); ok {
+		r.fields["availabilityDomain"] = inst.fields["availabilityDomain"]
+		r.fields["compartmentId"] = inst.fields["compartmentId"]
+	}
+}
+
+// attachDrg places the attachment in the compartment of its DRG, as the
+// create call doesn't say which compartment it belongs in.
+func attachDrg(s *Server, ctx *requestContext, r *record) {
+	if drg, ok := s.core("drgs").get(r.str("drgId")); ok {
+		r.fields["compartmentId"] = drg.fields["compartmentId"]
+	}
+}
+
+// createVCN creates the default route table, security list and DHCP options
+// every VCN comes with.
+func createVCN(s *Server, ctx *requestContext, r *record) {
+	defaults := []struct{ collection, kind, field string }{
+		{"routeTables", "routetable", "defaultRouteTableId"},
+		{"securityLists", "securitylist", "defaultSecurityListId"},
+		{"dhcps", "dhcpoptions", "defaultDhcpOptionsId"},
+	}
+	for _, d := range defaults {
+		rec := newRecord(map[string]interface{}{
+			"id":             newOCID(d.kind, ctx.region),
+			"compartmentId":  r.fields["compartmentId"],
+			"displayName":    fmt.Sprintf("Default %s for %s", d.kind, r.str("displayName")),
+			"lifecycleState": "AVAILABLE",
+			"timeCreated":    now(),
+			"vcnId":          r.fields["id"],
+		})
+		s.core(d.collection).add(rec)
+		r.fields[d.field] = rec.fields["id"]
+	}
+	if label := r.str("dnsLabel"); label != "" {
+		r.fields["vcnDomainName"] = label + ".oraclevcn.com"
+	}
+}
+
+func createSubnet(s *Server, ctx *requestContext, r *record) {
+	r.fields["virtualRouterIp"] = "10.0.0.1"
+	r.fields["virtualRouterMac"] = "00:00:17:00:00:01"
+	if _, ok := r.fields["dhcpOptionsId"]; !ok {
+		if vcn, ok := s.core("vcns").get(r.str("vcnId")); ok {
+			r.fields["dhcpOptionsId"] = vcn.fields["defaultDhcpOptionsId"]
+		}
+	}
+}
+
+// createVolume restores a volume from its source, a volume backup or another
+// volume, when it has one.
+func createVolume(s *Server, ctx *requestContext, r *record) {
+	var source *record
+	if id := r.str("volumeBackupId"); id != "" {
+		source, _ = s.core("volumeBackups").get(id)
+	}
+	if details, ok := r.fields["sourceDetails"].(map[string]interface{}); ok {
+		id, _ := details["id"].(string)
+		switch details["type"] {
+		case "volumeBackup":
+			source, _ = s.core("volumeBackups").get(id)
+		case "volume":
+			source, _ = s.core("volumes").get(id)
+		}
+	}
+	if source != nil {
+		r.transition([]string{"RESTORING", "AVAILABLE"})
+		if _, ok := r.fields["sizeInMBs"]; !ok {
+			r.fields["sizeInMBs"] = source.fields["sizeInMBs"]
+		}
+	}
+	if _, ok := r.fields["sizeInMBs"]; !ok {
+		r.fields["sizeInMBs"] = json.Number("262144")
+	}
+}
+
+func createVolumeBackup(s *Server, ctx *requestContext, r *record) {
+	r.fields["timeRequestReceived"] = r.fields["timeCreated"]
+	if vol, ok := s.core("volumes").get(r.str("volumeId")); ok {
+		r.fields["compartmentId"] = vol.fields["compartmentId"]
+		r.fields["sizeInMBs"] = vol.fields["sizeInMBs"]
+		r.fields["uniqueSizeInMBs"] = vol.fields["sizeInMBs"]
+	}
+}
+
+// attachVolume fills in the iSCSI target details of an attachment.
+func attachVolume(s *Server, ctx *requestContext, r *record) {
+	copyFromInstance(s, ctx, r)
+	r.fields["attachmentType"] = r.fields["type"]
+	delete(r.fields, "type")
+	if _, ok := r.fields["isReadOnly"]; !ok {
+		r.fields["isReadOnly"] = false
+	}
+	if r.str("attachmentType") == "iscsi" {
+		r.fields["iqn"] = "iqn.2015-12.com.oracleiaas:" + randomHex(8)
+		r.fields["ipv4"] = "169.254.2.2"
+		r.fields["port"] = json.Number("3260")
+		r.fields["chapUsername"] = r.str("id")
+		r.fields["chapSecret"] = randomHex(8)
+	}
+}
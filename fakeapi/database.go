@@ -0,0 +1,213 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Names of the seeded DB system shapes and database versions.
+var (
+	DBSystemShapes = []string{"BM.DenseIO1.36", "BM.HighIO1.36", "BM.RACLocalStorage1.72", "VM.Standard1.1", "VM.Standard1.2"}
+	DBVersions     = []string{"11.2.0.4", "12.1.0.2", "12.2.0.1"}
+)
+
+func databaseCollections() map[string]*collection {
+	shapes := &collection{name: "dbSystemShapes", idField: "name", readOnly: true}
+	for _, name := range DBSystemShapes {
+		shapes.add(newRecord(map[string]interface{}{
+			"name":               name,
+			"shape":              name,
+			"availableCoreCount": json.Number("36"),
+		}))
+	}
+
+	versions := &collection{name: "dbVersions", idField: "version", readOnly: true}
+	for _, v := range DBVersions {
+		versions.add(newRecord(map[string]interface{}{"version": v}))
+	}
+
+	operations := &collection{name: "supportedOperations", readOnly: true}
+	for _, op := range []string{"LaunchDbSystem", "UpdateDbSystem", "TerminateDbSystem", "DbNodeAction"} {
+		operations.add(newRecord(map[string]interface{}{"id": op}))
+	}
+
+	return newCollections(
+		shapes,
+		versions,
+		operations,
+		&collection{
+			name:     "databases",
+			kind:     "database",
+			created:  provisioned,
+			deleted:  terminated,
+			onCreate: createDatabase,
+		},
+		&collection{
+			name:     "dbHomes",
+			kind:     "dbhome",
+			created:  provisioned,
+			deleted:  terminated,
+			onCreate: createDBHome,
+			onDelete: deleteDBHome,
+		},
+		&collection{
+			name:     "dbNodes",
+			kind:     "dbnode",
+			readOnly: true,
+			actions: map[string][]string{
+				"START":     {"STARTING", "AVAILABLE"},
+				"STOP":      {"STOPPING", "STOPPED"},
+				"RESET":     {"STOPPING", "STARTING", "AVAILABLE"},
+				"SOFTRESET": {"STOPPING", "STARTING", "AVAILABLE"},
+			},
+		},
+		&collection{
+			name:     "dbSystems",
+			kind:     "dbsystem",
+			created:  provisioned,
+			deleted:  terminated,
+			updated:  []string{"UPDATING", "AVAILABLE"},
+			onCreate: launchDBSystem,
+			onDelete: terminateDBSystem,
+		},
+	)
+}
+
+func (s *Server) database(name string) *collection {
+	return s.services["database"][name]
+}
+
+// launchDBSystem creates the DB home, database and nodes of a new DB system.
+// Like the service, it accepts a launch with more cores than the shape has and
+// only fails it once provisioning is under way.
+func launchDBSystem(s *Server, ctx *requestContext, r *record) {
+	r.fields["listenerPort"] = json.Number("1521")
+	if shape, ok := s.database("dbSystemShapes").get(r.str("shape")); ok {
+		requested, _ := r.fields["cpuCoreCount"].(json.Number)
+		cores, _ := requested.Int64()
+		available, _ := shape.fields["availableCoreCount"].(json.Number).Int64()
+		if cores > available {
+			r.transition([]string{"PROVISIONING", "FAILED"})
+			r.fields["lifecycleDetails"] = fmt.Sprintf("Shape %s has %d cores available, %d were requested", r.str("shape"), available, cores)
+			return
+		}
+	}
+	if _, ok := r.fields["databaseEdition"]; !ok {
+		r.fields["databaseEdition"] = "ENTERPRISE_EDITION"
+	}
+	if _, ok := r.fields["diskRedundancy"]; !ok {
+		r.fields["diskRedundancy"] = "HIGH"
+	}
+
+	home, _ := r.fields["dbHome"].(map[string]interface{})
+	delete(r.fields, "dbHome")
+
+	dbHome := newRecord(map[string]interface{}{
+		"id":             newOCID("dbhome", ctx.region),
+		"compartmentId":  r.fields["compartmentId"],
+		"dbSystemId":     r.fields["id"],
+		"dbVersion":      home["dbVersion"],
+		"displayName":    home["displayName"],
+		"lifecycleState": "AVAILABLE",
+		"timeCreated":    now(),
+	})
+	s.database("dbHomes").add(dbHome)
+	db := newDatabase(ctx, dbHome, home["database"])
+	db.fields["lifecycleState"] = "AVAILABLE"
+	s.database("databases").add(db)
+
+	nodes := 1
+	if strings.Contains(r.str("shape"), "RAC") {
+		nodes = 2
+	}
+	for i := 0; i < nodes; i++ {
+		s.database("dbNodes").add(newRecord(map[string]interface{}{
+			"id":             newOCID("dbnode", ctx.region),
+			"compartmentId":  r.fields["compartmentId"],
+			"dbSystemId":     r.fields["id"],
+			"hostname":       fmt.Sprintf("%s%d", r.str("hostname"), i+1),
+			"lifecycleState": "AVAILABLE",
+			"timeCreated":    now(),
+			"vnicId":         newOCID("vnic", ctx.region),
+		}))
+	}
+}
+
+// createDBHome creates the first database of a DB home added to an existing
+// DB system.
+func createDBHome(s *Server, ctx *requestContext, r *record) {
+	if system, ok := s.database("dbSystems").get(r.str("dbSystemId")); ok {
+		r.fields["compartmentId"] = system.fields["compartmentId"]
+	}
+	database := r.fields["database"]
+	delete(r.fields, "database")
+	db := newDatabase(ctx, r, database)
+	db.transition(provisioned)
+	s.database("databases").add(db)
+}
+
+// createDatabase fills in a database added to an existing DB home.
+func createDatabase(s *Server, ctx *requestContext, r *record) {
+	db, _ := r.fields["database"].(map[string]interface{})
+	delete(r.fields, "database")
+	// Databases have no display name.
+	delete(r.fields, "displayName")
+	dbName, _ := db["dbName"].(string)
+	r.fields["dbName"] = dbName
+	r.fields["dbUniqueName"] = dbUniqueName(dbName)
+	if home, ok := s.database("dbHomes").get(r.str("dbHomeId")); ok {
+		r.fields["compartmentId"] = home.fields["compartmentId"]
+	}
+}
+
+// newDatabase makes the record of a database in home from the database
+// details of a request. The admin password is not kept.
+func newDatabase(ctx *requestContext, home *record, details interface{}) *record {
+	db, _ := details.(map[string]interface{})
+	dbName, _ := db["dbName"].(string)
+	return newRecord(map[string]interface{}{
+		"id":            newOCID("database", ctx.region),
+		"compartmentId": home.fields["compartmentId"],
+		"dbHomeId":      home.fields["id"],
+		"dbName":        dbName,
+		"dbUniqueName":  dbUniqueName(dbName),
+		"timeCreated":   now(),
+	})
+}
+
+func dbUniqueName(dbName string) string {
+	return fmt.Sprintf("%s_%s", dbName, strings.ToLower(randomHex(3)))
+}
+
+// deleteDBHome terminates the databases in the DB home.
+func deleteDBHome(s *Server, r *record) {
+	c := s.database("databases")
+	for _, id := range c.order {
+		if rec := c.records[id]; rec.str("dbHomeId") == r.str("id") {
+			rec.transition(terminated)
+		}
+	}
+}
+
+// terminateDBSystem terminates everything launched with the DB system.
+func terminateDBSystem(s *Server, r *record) {
+	homes := map[string]bool{}
+	for _, name := range []string{"dbHomes", "dbNodes"} {
+		c := s.database(name)
+		for _, id := range c.order {
+			if rec := c.records[id]; rec.str("dbSystemId") == r.str("id") {
+				rec.fields["lifecycleState"] = "TERMINATED"
+				homes[id] = true
+			}
+		}
+	}
+	c := s.database("databases")
+	for _, id := range c.order {
+		if rec := c.records[id]; homes[rec.str("dbHomeId")] {
+			rec.fields["lifecycleState"] = "TERMINATED"
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	activated = []string{"CREATING", "ACTIVE"}
+	deleted   = []string{"DELETING", "DELETED"}
+)
+
+func identityCollections() map[string]*collection {
+	ads := &collection{name: "availabilityDomains", idField: "name", readOnly: true}
+	for _, name := range AvailabilityDomains {
+		ads.add(newRecord(map[string]interface{}{"name": name}))
+	}
+
+	return newCollections(
+		ads,
+		&collection{name: "compartments", kind: "compartment", global: true, created: activated, onCreate: inTenancy},
+		&collection{name: "groups", kind: "group", global: true, created: activated, deleted: deleted, onCreate: inTenancy},
+		&collection{name: "policies", kind: "policy", global: true, created: activated, deleted: deleted, onCreate: inTenancy},
+		&collection{name: "userGroupMemberships", kind: "groupmembership", global: true, created: activated, deleted: deleted, onCreate: inTenancy},
+		&collection{name: "users", kind: "user", global: true, created: activated, deleted: deleted, onCreate: createUser},
+		&collection{
+			name:        "apiKeys",
+			idField:     "fingerprint",
+			parentField: "userId",
+			created:     activated,
+			deleted:     deleted,
+			onCreate:    uploadAPIKey,
+		},
+		&collection{
+			name:        "swiftPasswords",
+			kind:        "credential",
+			global:      true,
+			parentField: "userId",
+			created:     []string{"ACTIVE"},
+			onCreate:    createSwiftPassword,
+		},
+	)
+}
+
+func init() {
+	subResourceHandlers["users/uiPassword"] = func(s *Server, ctx *requestContext, r *record) {
+		if ctx.r.Method != http.MethodPost {
+			writeError(ctx.w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+			return
+		}
+		writeJSON(ctx.w, http.StatusOK, map[string]interface{}{
+			"password":       randomHex(10),
+			"userId":         r.str("id"),
+			"timeCreated":    now(),
+			"lifecycleState": "ACTIVE",
+			"inactiveStatus": 0,
+		})
+	}
+
+	subResourceHandlers["users/state"] = func(s *Server, ctx *requestContext, r *record) {
+		if ctx.r.Method != http.MethodPut {
+			writeError(ctx.w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+			return
+		}
+		if blocked, ok := ctx.body["blocked"].(bool); ok && blocked {
+			r.fields["inactiveStatus"] = json.Number("4")
+		} else {
+			r.fields["inactiveStatus"] = json.Number("0")
+		}
+		writeRecord(ctx.w, http.StatusOK, r)
+	}
+}
+
+// seedTenancy creates the compartment a new tenancy comes with.
+func seedTenancy(s *Server, ctx *requestContext) {
+	s.services["identity"]["compartments"].add(newRecord(map[string]interface{}{
+		"id":             newOCID("compartment", ""),
+		"compartmentId":  ctx.tenancy,
+		"name":           "Default",
+		"description":    "Default compartment",
+		"lifecycleState": "ACTIVE",
+		"timeCreated":    now(),
+	}))
+}
+
+// inTenancy places identity resources in the caller's tenancy.
+func inTenancy(s *Server, ctx *requestContext, r *record) {
+	if r.str("compartmentId") == "" {
+		r.fields["compartmentId"] = ctx.tenancy
+	}
+}
+
+func createUser(s *Server, ctx *requestContext, r *record) {
+	inTenancy(s, ctx, r)
+	r.fields["inactiveStatus"] = json.Number("0")
+}
+
+// uploadAPIKey derives the key's fingerprint from the uploaded PEM.
+func uploadAPIKey(s *Server, ctx *requestContext, r *record) {
+	key := r.str("key")
+	delete(r.fields, "key")
+
+	sum := md5.Sum([]byte(strings.TrimSpace(key)))
+	pairs := make([]string, len(sum))
+	for i, b := range sum {
+		pairs[i] = fmt.Sprintf("%02x", b)
+	}
+	fingerprint := strings.Join(pairs, ":")
+
+	r.fields["fingerprint"] = fingerprint
+	r.fields["keyId"] = fmt.Sprintf("%s/%s/%s", ctx.tenancy, r.str("userId"), fingerprint)
+	r.fields["keyValue"] = key
+	r.fields["timeModified"] = r.fields["timeCreated"]
+}
+
+func createSwiftPassword(s *Server, ctx *requestContext, r *record) {
+	r.fields["password"] = randomHex(10)
+	r.fields["inactiveStatus"] = json.Number("0")
+	r.fields["expiresOn"] = time.Now().UTC().AddDate(1, 0, 0).Format(time.RFC3339)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultTokenLifetime is how long security tokens issued by the fake auth
+// service are valid for.
+const DefaultTokenLifetime = time.Hour
+
+var (
+	tokenKeyIDReg   = regexp.MustCompile(`keyId="ST\$([^"]+)"`)
+	x509KeyIDReg    = regexp.MustCompile(`keyId="([^/"]+)/fed-x509/`)
+	opcTenantPrefix = "opc-tenant:"
+)
+
+type securityToken struct {
+	tenancy string
+	expires time.Time
+}
+
+// MetadataServer stands in for the instance metadata service that hands an
+// instance its region and identity certificate.
+type MetadataServer struct {
+	// Region is the short region name the instance reports.
+	Region string
+
+	srv     *httptest.Server
+	certPEM []byte
+	keyPEM  []byte
+	caPEM   []byte
+}
+
+// NewMetadataServer starts a metadata service for an instance in tenancyOCID.
+func NewMetadataServer(tenancyOCID string) *MetadataServer {
+	m := &MetadataServer{Region: "phx"}
+
+	caKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "PKISVC Identity Intermediate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, _ := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject: pkix.Name{
+			CommonName:         newOCID("instance", m.Region),
+			OrganizationalUnit: []string{"opc-certtype:instance", opcTenantPrefix + tenancyOCID},
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(24 * time.Hour),
+	}
+	leafDER, _ := x509.CreateCertificate(rand.Reader, leaf, ca, &key.PublicKey, caKey)
+
+	m.caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	m.certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	m.keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	m.srv = httptest.NewServer(m)
+	return m
+}
+
+// URL is suitable for baremetal.InstancePrincipalOptions.MetadataURL.
+func (m *MetadataServer) URL() string {
+	return m.srv.URL + "/opc/v1"
+}
+
+// Close shuts the server down.
+func (m *MetadataServer) Close() {
+	m.srv.Close()
+}
+
+func (m *MetadataServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	switch r.URL.Path {
+	case "/opc/v1/instance/region":
+		body = []byte(m.Region)
+	case "/opc/v1/identity/cert.pem":
+		body = m.certPEM
+	case "/opc/v1/identity/key.pem":
+		body = m.keyPEM
+	case "/opc/v1/identity/intermediate.pem":
+		body = m.caPEM
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(body)
+}
+
+// TokensIssued is the number of security tokens the auth service has issued.
+func (s *Server) TokensIssued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tokens)
+}
+
+// authenticate returns the tenancy a request was signed for, either with a
+// user's API key or a security token.
+func (s *Server) authenticate(r *http.Request) (tenancy string, ok bool) {
+	auth := r.Header.Get("Authorization")
+	if m := tokenKeyIDReg.FindStringSubmatch(auth); m != nil {
+		token, found := s.tokens[m[1]]
+		if !found || time.Now().After(token.expires) {
+			return "", false
+		}
+		return token.tenancy, true
+	}
+	if m := keyIDReg.FindStringSubmatch(auth); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
+
+// serveAuth issues security tokens in exchange for instance certificates:
+// POST /v1/x509.
+func (s *Server) serveAuth(ctx *requestContext) {
+	w, r := ctx.w, ctx.r
+	if r.Method != http.MethodPost || strings.Join(ctx.parts, "/") != "v1/x509" {
+		writeNotFound(w)
+		return
+	}
+	if !x509KeyIDReg.MatchString(r.Header.Get("Authorization")) {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", "The request must be signed with an instance certificate.")
+		return
+	}
+
+	certificate, _ := ctx.body["certificate"].(string)
+	der, err := base64.StdEncoding.DecodeString(certificate)
+	var cert *x509.Certificate
+	if err == nil {
+		cert, err = x509.ParseCertificate(der)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", "Could not parse certificate")
+		return
+	}
+
+	tenancy := ""
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if strings.HasPrefix(ou, opcTenantPrefix) {
+			tenancy = strings.TrimPrefix(ou, opcTenantPrefix)
+		}
+	}
+	if tenancy != ctx.tenancy {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", "The certificate does not belong to the signing tenancy.")
+		return
+	}
+
+	expires := time.Now().Add(s.TokenLifetime)
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"exp":    expires.Unix(),
+		"sub":    cert.Subject.CommonName,
+		"tenant": tenancy,
+	})
+	token := fmt.Sprintf("%s.%s.%s",
+		base64.RawURLEncoding.EncodeToString(header),
+		base64.RawURLEncoding.EncodeToString(claims),
+		randomHex(16))
+	s.tokens[token] = securityToken{tenancy: tenancy, expires: expires}
+
+	writeJSON(w, http.StatusOK, map[string]string{"token": token})
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type InstancePrincipalTestSuite struct {
+	suite.Suite
+	Server   *Server
+	Metadata *MetadataServer
+	Options  *baremetal.InstancePrincipalOptions
+}
+
+func (s *InstancePrincipalTestSuite) SetupTest() {
+	s.Server = NewServer()
+	s.Metadata = NewMetadataServer("ocid1.tenancy.oc1..instancetenancy")
+	s.Options = &baremetal.InstancePrincipalOptions{
+		MetadataURL: s.Metadata.URL(),
+		UrlTemplate: s.Server.URLTemplate(),
+		Transport:   &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+}
+
+func (s *InstancePrincipalTestSuite) TearDownTest() {
+	s.Server.Close()
+	s.Metadata.Close()
+}
+
+func (s *InstancePrincipalTestSuite) client(signer *baremetal.InstancePrincipalSigner) *baremetal.Client {
+	client, err := baremetal.NewClient("", signer.TenancyOCID(), "",
+		baremetal.CustomSigner(signer),
+		baremetal.Region(signer.Region()),
+		baremetal.UrlTemplate(s.Options.UrlTemplate),
+		baremetal.CustomTransport(s.Options.Transport),
+	)
+	s.Require().NoError(err)
+	return client
+}
+
+func (s *InstancePrincipalTestSuite) TestSignsWithSecurityToken() {
+	signer, err := baremetal.NewInstancePrincipalSigner(s.Options)
+	s.Require().NoError(err)
+	s.Equal("us-phoenix-1", signer.Region())
+	s.Equal("ocid1.tenancy.oc1..instancetenancy", signer.TenancyOCID())
+	s.Equal(1, s.Server.TokensIssued())
+
+	client := s.client(signer)
+	compartments, err := client.ListCompartments(nil)
+	s.Require().NoError(err)
+	s.Require().Len(compartments.Compartments, 1)
+	s.Equal(signer.TenancyOCID(), compartments.Compartments[0].CompartmentID)
+
+	// The token is reused while it is valid.
+	_, err = client.ListCompartments(nil)
+	s.Require().NoError(err)
+	s.Equal(1, s.Server.TokensIssued())
+}
+
+func (s *InstancePrincipalTestSuite) TestRefreshesExpiringToken() {
+	s.Server.TokenLifetime = time.Minute
+
+	signer, err := baremetal.NewInstancePrincipalSigner(s.Options)
+	s.Require().NoError(err)
+	s.Equal(1, s.Server.TokensIssued())
+
+	_, err = s.client(signer).ListCompartments(nil)
+	s.Require().NoError(err)
+	s.Equal(2, s.Server.TokensIssued())
+}
+
+func (s *InstancePrincipalTestSuite) TestRejectsUnknownToken() {
+	signer, err := baremetal.NewInstancePrincipalSigner(s.Options)
+	s.Require().NoError(err)
+
+	s.Server.mu.Lock()
+	s.Server.tokens = map[string]securityToken{}
+	s.Server.mu.Unlock()
+
+	_, err = s.client(signer).ListCompartments(nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NotAuthenticated")
+}
+
+func (s *InstancePrincipalTestSuite) TestMetadataUnavailable() {
+	s.Metadata.Close()
+	_, err := baremetal.NewInstancePrincipalSigner(s.Options)
+	s.Error(err)
+}
+
+func TestInstancePrincipalTestSuite(t *testing.T) {
+	suite.Run(t, new(InstancePrincipalTestSuite))
+}
@@ -0,0 +1,462 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Names of the seeded load balancer shapes, policies and protocols.
+var (
+	LoadBalancerShapes    = []string{"100Mbps", "400Mbps", "8000Mbps"}
+	LoadBalancerPolicies  = []string{"ROUND_ROBIN", "LEAST_CONNECTIONS", "IP_HASH"}
+	LoadBalancerProtocols = []string{"HTTP", "TCP"}
+)
+
+// Every load balancer change is carried out by a work request that moves
+// ACCEPTED -> IN_PROGRESS -> SUCCEEDED as it, or its load balancer, is read.
+// The change only becomes visible once the work request has succeeded.
+var workRequestStates = []string{"ACCEPTED", "IN_PROGRESS", "SUCCEEDED"}
+
+// Changes the API can only validate once accepted fail instead, with error
+// details saying why.
+var failedWorkRequestStates = []string{"ACCEPTED", "IN_PROGRESS", "FAILED"}
+
+type workRequest struct {
+	*record
+	apply   func()
+	failure string
+}
+
+type loadBalancerService struct {
+	loadBalancers *collection
+	workRequests  map[string]*workRequest
+	workOrder     []string
+	static        map[string][]string
+}
+
+func newLoadBalancerService() *loadBalancerService {
+	return &loadBalancerService{
+		loadBalancers: &collection{name: "loadBalancers", kind: "loadbalancer"},
+		workRequests:  map[string]*workRequest{},
+		static: map[string][]string{
+			"loadBalancerShapes":    LoadBalancerShapes,
+			"loadBalancerPolicies":  LoadBalancerPolicies,
+			"loadBalancerProtocols": LoadBalancerProtocols,
+		},
+	}
+}
+
+// submit queues a work request against the load balancer lbID and writes
+// its ID to the response.
+func (lb *loadBalancerService) submit(ctx *requestContext, lbID, kind string, apply func()) {
+	wr := &workRequest{
+		record: newRecord(map[string]interface{}{
+			"id":             newOCID("loadbalancerworkrequest", ctx.region),
+			"loadBalancerId": lbID,
+			"type":           kind,
+			"message":        kind + " accepted",
+			"timeAccepted":   now(),
+			"errorDetails":   []interface{}{},
+		}),
+		apply: apply,
+	}
+	wr.transition(workRequestStates)
+
+	id := wr.str("id")
+	lb.workRequests[id] = wr
+	lb.workOrder = append(lb.workOrder, id)
+
+	ctx.w.Header().Set("opc-work-request-id", id)
+	ctx.w.WriteHeader(http.StatusNoContent)
+}
+
+// reject queues a work request against the load balancer lbID that fails
+// with message, and writes its ID to the response.
+func (lb *loadBalancerService) reject(ctx *requestContext, lbID, kind, message string) {
+	lb.submit(ctx, lbID, kind, nil)
+	wr := lb.workRequests[lb.workOrder[len(lb.workOrder)-1]]
+	wr.transition(failedWorkRequestStates)
+	wr.failure = message
+}
+
+// tick advances every outstanding work request of a load balancer.
+func (lb *loadBalancerService) tick(lbID string) {
+	for _, id := range lb.workOrder {
+		wr := lb.workRequests[id]
+		if wr.str("loadBalancerId") != lbID || len(wr.pending) == 0 {
+			continue
+		}
+		wr.advance()
+		if wr.state() == "SUCCEEDED" {
+			wr.fields["timeFinished"] = now()
+			wr.fields["message"] = wr.str("type") + " succeeded"
+			if wr.apply != nil {
+				wr.apply()
+				wr.apply = nil
+			}
+		}
+		if wr.state() == "FAILED" {
+			wr.fields["timeFinished"] = now()
+			wr.fields["message"] = wr.str("type") + " failed"
+			wr.fields["errorDetails"] = []interface{}{
+				map[string]interface{}{"errorCode": "BAD_INPUT", "message": wr.failure},
+			}
+		}
+	}
+}
+
+// invalidSSLConfig explains why the SSL configuration of a listener or
+// backend set can't be used on the load balancer, or returns "".
+func (lb *loadBalancerService) invalidSSLConfig(rec *record, body map[string]interface{}) string {
+	ssl, ok := body["sslConfiguration"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := ssl["certificateName"].(string)
+	if _, ok := lb.children(rec, "certificates")[name]; !ok {
+		return fmt.Sprintf("Invalid SSL configuration: load balancer %s has no certificate named %s", rec.str("id"), name)
+	}
+	return ""
+}
+
+func (lb *loadBalancerService) serve(s *Server, ctx *requestContext) {
+	parts := ctx.parts
+	w, r := ctx.w, ctx.r
+
+	if names, ok := lb.static[parts[0]]; ok && len(parts) == 1 && r.Method == http.MethodGet {
+		list := make([]map[string]string, len(names))
+		for i, name := range names {
+			list[i] = map[string]string{"name": name}
+		}
+		writeJSON(w, http.StatusOK, list)
+		return
+	}
+
+	if parts[0] == "loadBalancerWorkRequests" && len(parts) == 2 && r.Method == http.MethodGet {
+		wr, ok := lb.workRequests[parts[1]]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		lb.tick(wr.str("loadBalancerId"))
+		writeRecord(w, http.StatusOK, wr.record)
+		return
+	}
+
+	if parts[0] != "loadBalancers" {
+		writeNotFound(w)
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			res, next := lb.loadBalancers.list(r.URL.Query())
+			if next != "" {
+				w.Header().Set("opc-next-page", next)
+			}
+			list := make([]interface{}, len(res))
+			for i, rec := range res {
+				list[i] = rec.fields
+			}
+			writeJSON(w, http.StatusOK, list)
+		case http.MethodPost:
+			lb.create(ctx)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	rec, ok := lb.loadBalancers.get(parts[1])
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if r.Method == http.MethodGet {
+		lb.tick(parts[1])
+	}
+
+	if len(parts) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			writeRecord(w, http.StatusOK, rec)
+		case http.MethodPut:
+			update := ctx.body
+			lb.submit(ctx, parts[1], "UpdateLoadBalancer", func() { rec.merge(update) })
+		case http.MethodDelete:
+			rec.fields["lifecycleState"] = "DELETING"
+			lb.submit(ctx, parts[1], "DeleteLoadBalancer", func() {
+				rec.fields["lifecycleState"] = "DELETED"
+			})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	switch parts[2] {
+	case "workRequests":
+		var list []interface{}
+		for _, id := range lb.workOrder {
+			if wr := lb.workRequests[id]; wr.str("loadBalancerId") == parts[1] {
+				list = append(list, wr.fields)
+			}
+		}
+		if list == nil {
+			list = []interface{}{}
+		}
+		writeJSON(w, http.StatusOK, list)
+	case "backendSets":
+		lb.serveBackendSets(ctx, rec, parts[3:])
+	case "listeners":
+		lb.serveNamed(ctx, rec, "listeners", "listener", "name", parts[3:])
+	case "certificates":
+		lb.serveNamed(ctx, rec, "certificates", "certificate", "certificateName", parts[3:])
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (lb *loadBalancerService) create(ctx *requestContext) {
+	rec := newRecord(ctx.body)
+	id := newOCID("loadbalancer", ctx.region)
+	rec.fields["id"] = id
+	rec.fields["timeCreated"] = now()
+	rec.fields["lifecycleState"] = "CREATING"
+
+	n := len(lb.loadBalancers.order)
+	rec.fields["ipAddresses"] = []interface{}{
+		map[string]interface{}{"ipAddress": fmt.Sprintf("129.146.%d.%d", 100+n/250, n%250+2), "isPublic": true},
+	}
+
+	// Inline children are keyed by name, as in the API's CreateLoadBalancerDetails.
+	for _, key := range []string{"backendSets", "listeners", "certificates"} {
+		if _, ok := rec.fields[key].(map[string]interface{}); !ok {
+			rec.fields[key] = map[string]interface{}{}
+		}
+		for name, v := range rec.fields[key].(map[string]interface{}) {
+			if child, ok := v.(map[string]interface{}); ok {
+				if key == "certificates" {
+					child["certificateName"] = name
+					redactCertificate(child)
+				} else {
+					child["name"] = name
+				}
+				if key == "backendSets" {
+					nameBackends(child)
+				}
+			}
+		}
+	}
+
+	lb.loadBalancers.add(rec)
+	lb.submit(ctx, id, "CreateLoadBalancer", func() {
+		rec.fields["lifecycleState"] = "ACTIVE"
+	})
+}
+
+func (lb *loadBalancerService) children(rec *record, key string) map[string]interface{} {
+	m, ok := rec.fields[key].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		rec.fields[key] = m
+	}
+	return m
+}
+
+// serveNamed handles listeners and certificates, which are plain maps on the
+// load balancer keyed by their name field.
+func (lb *loadBalancerService) serveNamed(ctx *requestContext, rec *record, key, noun, nameField string, ids []string) {
+	w, r := ctx.w, ctx.r
+	m := lb.children(rec, key)
+	lbID := rec.str("id")
+	kind := strings.Replace(strings.Title(noun), " ", "", -1)
+
+	if len(ids) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, sortedValues(m))
+		case http.MethodPost:
+			name, _ := ctx.body[nameField].(string)
+			if _, exists := m[name]; exists {
+				writeError(w, http.StatusConflict, "Conflict", fmt.Sprintf("Load balancer %s already has a %s named %s", lbID, noun, name))
+				return
+			}
+			child := ctx.body
+			if key == "certificates" {
+				redactCertificate(child)
+			} else if msg := lb.invalidSSLConfig(rec, child); msg != "" {
+				lb.reject(ctx, lbID, "Create"+kind, msg)
+				return
+			}
+			lb.submit(ctx, lbID, "Create"+kind, func() { m[name] = child })
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	name := ids[0]
+	child, ok := m[name].(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Load balancer %s has no %s named %s", lbID, noun, name))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, child)
+	case http.MethodPut:
+		update := ctx.body
+		if msg := lb.invalidSSLConfig(rec, update); msg != "" {
+			lb.reject(ctx, lbID, "Update"+kind, msg)
+			return
+		}
+		lb.submit(ctx, lbID, "Update"+kind, func() {
+			for k, v := range update {
+				child[k] = v
+			}
+		})
+	case http.MethodDelete:
+		lb.submit(ctx, lbID, "Delete"+kind, func() { delete(m, name) })
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+	}
+}
+
+func (lb *loadBalancerService) serveBackendSets(ctx *requestContext, rec *record, ids []string) {
+	if len(ids) == 0 {
+		if ctx.r.Method == http.MethodPost {
+			nameBackends(ctx.body)
+		}
+		lb.serveNamed(ctx, rec, "backendSets", "backend set", "name", ids)
+		return
+	}
+
+	sets := lb.children(rec, "backendSets")
+	set, ok := sets[ids[0]].(map[string]interface{})
+	if !ok || len(ids) == 1 {
+		if ok && ctx.r.Method == http.MethodPut {
+			nameBackends(ctx.body)
+		}
+		lb.serveNamed(ctx, rec, "backendSets", "backend set", "name", ids)
+		return
+	}
+
+	w, r := ctx.w, ctx.r
+	lbID := rec.str("id")
+
+	switch ids[1] {
+	case "healthChecker":
+		switch r.Method {
+		case http.MethodGet:
+			hc, _ := set["healthChecker"].(map[string]interface{})
+			writeJSON(w, http.StatusOK, hc)
+		case http.MethodPut:
+			update := ctx.body
+			lb.submit(ctx, lbID, "UpdateHealthChecker", func() { set["healthChecker"] = update })
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+	case "backends":
+		backends, _ := set["backends"].([]interface{})
+		if len(ids) == 2 {
+			switch r.Method {
+			case http.MethodGet:
+				if backends == nil {
+					backends = []interface{}{}
+				}
+				writeJSON(w, http.StatusOK, backends)
+			case http.MethodPost:
+				backend := ctx.body
+				backend["name"] = backendName(backend)
+				lb.submit(ctx, lbID, "CreateBackend", func() {
+					list, _ := set["backends"].([]interface{})
+					set["backends"] = append(list, backend)
+				})
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+			}
+			return
+		}
+
+		name := ids[2]
+		var backend map[string]interface{}
+		for _, b := range backends {
+			if m, ok := b.(map[string]interface{}); ok && backendName(m) == name {
+				backend = m
+			}
+		}
+		if backend == nil {
+			writeError(w, http.StatusNotFound, "NotFound", fmt.Sprintf("Load balancer %s has no backend named %s in backend set %s", lbID, name, ids[0]))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, backend)
+		case http.MethodPut:
+			update := ctx.body
+			lb.submit(ctx, lbID, "UpdateBackend", func() {
+				for k, v := range update {
+					backend[k] = v
+				}
+			})
+		case http.MethodDelete:
+			lb.submit(ctx, lbID, "DeleteBackend", func() {
+				list, _ := set["backends"].([]interface{})
+				kept := []interface{}{}
+				for _, b := range list {
+					if m, ok := b.(map[string]interface{}); !ok || backendName(m) != name {
+						kept = append(kept, b)
+					}
+				}
+				set["backends"] = kept
+			})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+	default:
+		writeNotFound(w)
+	}
+}
+
+func backendName(b map[string]interface{}) string {
+	return fmt.Sprintf("%v:%v", b["ipAddress"], b["port"])
+}
+
+func nameBackends(set map[string]interface{}) {
+	backends, _ := set["backends"].([]interface{})
+	for _, b := range backends {
+		if m, ok := b.(map[string]interface{}); ok {
+			m["name"] = backendName(m)
+		}
+	}
+	if backends == nil {
+		set["backends"] = []interface{}{}
+	}
+}
+
+// redactCertificate drops the secrets the API never returns.
+func redactCertificate(c map[string]interface{}) {
+	delete(c, "privateKey")
+	delete(c, "passphrase")
+}
+
+func sortedValues(m map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]interface{}, len(keys))
+	for i, k := range keys {
+		list[i] = m[k]
+	}
+	return list
+}
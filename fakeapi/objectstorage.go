@@ -0,0 +1,253 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type object struct {
+	name        string
+	body        []byte
+	metadata    map[string]string
+	contentType string
+	etag        string
+	created     time.Time
+}
+
+type bucket struct {
+	*record
+	objects map[string]*object
+}
+
+type objectStorageService struct {
+	// buckets are keyed by namespace + "/" + name.
+	buckets map[string]*bucket
+	order   []string
+}
+
+func newObjectStorageService() *objectStorageService {
+	return &objectStorageService{buckets: map[string]*bucket{}}
+}
+
+// serve handles /n/{namespace}/b/{bucket}/o/{object}.
+func (st *objectStorageService) serve(s *Server, ctx *requestContext) {
+	parts := ctx.parts
+	w, r := ctx.w, ctx.r
+
+	if len(parts) == 0 || parts[0] != "n" {
+		writeNotFound(w)
+		return
+	}
+
+	if len(parts) == 1 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.Namespace)
+		return
+	}
+
+	namespace := parts[1]
+	if len(parts) < 3 || parts[2] != "b" {
+		writeNotFound(w)
+		return
+	}
+
+	if len(parts) == 3 {
+		switch r.Method {
+		case http.MethodGet:
+			st.listBuckets(ctx, namespace)
+		case http.MethodPost:
+			if !ctx.decode() {
+				return
+			}
+			name, _ := ctx.body["name"].(string)
+			key := namespace + "/" + name
+			if _, exists := st.buckets[key]; exists {
+				writeError(w, http.StatusConflict, "BucketAlreadyExists", fmt.Sprintf("Bucket %s already exists", name))
+				return
+			}
+			b := &bucket{record: newRecord(ctx.body), objects: map[string]*object{}}
+			b.fields["namespace"] = namespace
+			b.fields["createdBy"] = ctx.tenancy
+			b.fields["timeCreated"] = now()
+			if _, ok := b.fields["publicAccessType"]; !ok {
+				b.fields["publicAccessType"] = "NoPublicAccess"
+			}
+			st.buckets[key] = b
+			st.order = append(st.order, key)
+			writeRecord(w, http.StatusOK, b.record)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	key := namespace + "/" + parts[3]
+	b, ok := st.buckets[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, "BucketNotFound", fmt.Sprintf("Either the bucket '%s' does not exist in the namespace '%s' or you are not authorized to access it", parts[3], namespace))
+		return
+	}
+
+	if len(parts) == 4 {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			writeRecord(w, http.StatusOK, b.record)
+		case http.MethodPost:
+			if !ctx.decode() {
+				return
+			}
+			b.merge(ctx.body)
+			writeRecord(w, http.StatusOK, b.record)
+		case http.MethodDelete:
+			if len(b.objects) > 0 {
+				writeError(w, http.StatusConflict, "BucketNotEmpty", fmt.Sprintf("Bucket named '%s' is not empty", parts[3]))
+				return
+			}
+			delete(st.buckets, key)
+			for i, k := range st.order {
+				if k == key {
+					st.order = append(st.order[:i], st.order[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	if parts[4] != "o" {
+		writeNotFound(w)
+		return
+	}
+
+	if len(parts) == 5 {
+		st.listObjects(ctx, b)
+		return
+	}
+
+	// Object names may contain slashes.
+	name := strings.Join(parts[5:], "/")
+	switch r.Method {
+	case http.MethodPut:
+		o := &object{
+			name:        name,
+			body:        ctx.raw,
+			metadata:    map[string]string{},
+			contentType: r.Header.Get("Content-Type"),
+			etag:        randomHex(16),
+			created:     time.Now().UTC(),
+		}
+		for k, v := range r.Header {
+			if lower := strings.ToLower(k); strings.HasPrefix(lower, "opc-meta-") && len(v) > 0 {
+				o.metadata[lower] = v[0]
+			}
+		}
+		b.objects[name] = o
+		w.Header().Set("ETag", o.etag)
+		w.Header().Set("Content-Length", "0")
+		w.Header().Set("Content-MD5", o.md5())
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		o, ok := b.objects[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "ObjectNotFound", fmt.Sprintf("The object '%s' does not exist in bucket '%s' with namespace '%s'", name, parts[3], namespace))
+			return
+		}
+		for k, v := range o.metadata {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("ETag", o.etag)
+		w.Header().Set("Content-MD5", o.md5())
+		w.Header().Set("Content-Type", o.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.body)))
+		w.Header().Set("last-modified", o.created.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(o.body)
+		}
+	case http.MethodDelete:
+		if _, ok := b.objects[name]; !ok {
+			writeError(w, http.StatusNotFound, "ObjectNotFound", fmt.Sprintf("The object '%s' does not exist in bucket '%s' with namespace '%s'", name, parts[3], namespace))
+			return
+		}
+		delete(b.objects, name)
+		w.Header().Set("last-modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+	}
+}
+
+func (st *objectStorageService) listBuckets(ctx *requestContext, namespace string) {
+	compartmentID := ctx.r.URL.Query().Get("compartmentId")
+	list := []interface{}{}
+	for _, key := range st.order {
+		b := st.buckets[key]
+		if b.str("namespace") != namespace || (compartmentID != "" && b.str("compartmentId") != compartmentID) {
+			continue
+		}
+		list = append(list, map[string]interface{}{
+			"namespace":     b.fields["namespace"],
+			"name":          b.fields["name"],
+			"compartmentId": b.fields["compartmentId"],
+			"createdBy":     b.fields["createdBy"],
+			"timeCreated":   b.fields["timeCreated"],
+			"etag":          b.etag(),
+		})
+	}
+	writeJSON(ctx.w, http.StatusOK, list)
+}
+
+func (st *objectStorageService) listObjects(ctx *requestContext, b *bucket) {
+	query := ctx.r.URL.Query()
+	prefix, start, end := query.Get("prefix"), query.Get("start"), query.Get("end")
+
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		if strings.HasPrefix(name, prefix) && name >= start && (end == "" || name < end) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	next := ""
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < len(names) {
+		next = names[limit]
+		names = names[:limit]
+	}
+
+	objects := make([]interface{}, len(names))
+	for i, name := range names {
+		o := b.objects[name]
+		objects[i] = map[string]interface{}{
+			"name":        o.name,
+			"size":        len(o.body),
+			"md5":         o.md5(),
+			"timeCreated": o.created.Format(time.RFC3339),
+		}
+	}
+
+	writeJSON(ctx.w, http.StatusOK, map[string]interface{}{
+		"objects":       objects,
+		"prefixes":      []string{},
+		"nextStartWith": next,
+	})
+}
+
+func (o *object) md5() string {
+	sum := md5.Sum(o.body)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
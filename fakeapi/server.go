@@ -0,0 +1,406 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+// Package fakeapi is an in-process stand-in for the Oracle Bare Metal Cloud
+// Services API. It keeps resource state in memory and moves resources through
+// their lifecycle states as they are polled, so that the provider's acceptance
+// tests can run without a tenancy.
+//
+// Point the SDK at the server with its url template:
+//
+//	srv := fakeapi.NewServer()
+//	defer srv.Close()
+//	client, _ := baremetal.NewClient(user, tenancy, fingerprint,
+//		baremetal.UrlTemplate(srv.URLTemplate()),
+//		baremetal.CustomTransport(insecureTransport),
+//		...)
+//
+// Request signatures are not verified. Requests signed with a security token
+// are accepted while the token is valid; see NewMetadataServer for standing
+// in for an instance principal.
+package fakeapi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	coreVersion         = "20160918"
+	loadBalancerVersion = "20170115"
+
+	// DefaultNamespace is the object storage namespace reported by the server.
+	DefaultNamespace = "fakenamespace"
+)
+
+// Server is a fake OBMCS API endpoint.
+type Server struct {
+	// Namespace is returned by GetNamespace. Buckets may be created in
+	// any namespace.
+	Namespace string
+
+	// TokenLifetime is how long security tokens issued to instance
+	// principals are valid for.
+	TokenLifetime time.Duration
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	services map[string]map[string]*collection
+	lb       *loadBalancerService
+	storage  *objectStorageService
+	tenants  map[string]bool
+	tokens   map[string]securityToken
+	retries  map[string]retriedCreate
+}
+
+// retriedCreate is the record a create with an opc-retry-token made, which
+// is returned again to retries that use the same token.
+type retriedCreate struct {
+	raw string
+	rec *record
+}
+
+// NewServer starts a TLS server seeded with the static data (availability
+// domains, shapes, images, ...) that the real API provides.
+func NewServer() *Server {
+	s := &Server{
+		Namespace:     DefaultNamespace,
+		TokenLifetime: DefaultTokenLifetime,
+		tenants:       map[string]bool{},
+		tokens:        map[string]securityToken{},
+		retries:       map[string]retriedCreate{},
+	}
+	s.services = map[string]map[string]*collection{
+		"iaas":     coreCollections(),
+		"identity": identityCollections(),
+		"database": databaseCollections(),
+	}
+	s.lb = newLoadBalancerService()
+	s.storage = newObjectStorageService()
+	s.srv = httptest.NewTLSServer(s)
+	return s
+}
+
+// URL is the base URL of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// URLTemplate is suitable for baremetal.UrlTemplate and the provider's
+// url_template setting. The service and region are sent as path prefixes.
+func (s *Server) URLTemplate() string {
+	return s.srv.URL + "/%s/%s"
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// requestContext carries the parts of a request every handler needs.
+type requestContext struct {
+	w       http.ResponseWriter
+	r       *http.Request
+	region  string
+	tenancy string
+	parts   []string
+	body    map[string]interface{}
+	raw     []byte
+}
+
+// decode parses the JSON request body, writing a 400 if it is malformed.
+func (ctx *requestContext) decode() bool {
+	if len(ctx.raw) == 0 {
+		return true
+	}
+	dec := json.NewDecoder(bytes.NewReader(ctx.raw))
+	dec.UseNumber()
+	if err := dec.Decode(&ctx.body); err != nil {
+		writeError(ctx.w, http.StatusBadRequest, "InvalidParameter", "Could not parse request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+var keyIDReg = regexp.MustCompile(`keyId="([^/"]+)/`)
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("opc-request-id", newRequestID())
+
+	tenancy, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "NotAuthenticated", "The required information to complete authentication was not provided.")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 {
+		writeNotFound(w)
+		return
+	}
+
+	ctx := &requestContext{
+		w:       w,
+		r:       r,
+		region:  parts[1],
+		tenancy: tenancy,
+	}
+
+	if r.Body != nil {
+		ctx.raw, _ = ioutil.ReadAll(r.Body)
+	}
+
+	if !s.tenants[ctx.tenancy] {
+		s.tenants[ctx.tenancy] = true
+		seedTenancy(s, ctx)
+	}
+
+	service := parts[0]
+	if service == "objectstorage" {
+		// Object bodies are opaque, so the handler decodes JSON itself.
+		ctx.parts = parts[2:]
+		s.storage.serve(s, ctx)
+		return
+	}
+
+	if !ctx.decode() {
+		return
+	}
+
+	if service == "auth" {
+		ctx.parts = parts[2:]
+		s.serveAuth(ctx)
+		return
+	}
+
+	ctx.parts = parts[3:]
+	if len(ctx.parts) == 0 {
+		writeNotFound(w)
+		return
+	}
+
+	if service == "iaas" && parts[2] == loadBalancerVersion {
+		s.lb.serve(s, ctx)
+		return
+	}
+
+	collections, ok := s.services[service]
+	if !ok || parts[2] != coreVersion {
+		writeNotFound(w)
+		return
+	}
+	s.serveCollections(collections, ctx)
+}
+
+// serveCollections dispatches the generic create/read/update/delete/list
+// operations shared by the core, identity and database services.
+func (s *Server) serveCollections(collections map[string]*collection, ctx *requestContext) {
+	parts := ctx.parts
+	c, ok := collections[parts[0]]
+	if !ok {
+		writeNotFound(ctx.w)
+		return
+	}
+
+	if c.parentField != "" {
+		writeNotFound(ctx.w)
+		return
+	}
+
+	// Nested collections: /{parent}/{parentID}/{child}[/{childID}]
+	if len(parts) >= 3 {
+		if child, ok := collections[parts[2]]; ok && child.parentField != "" {
+			if _, ok := c.get(parts[1]); !ok {
+				writeNotFound(ctx.w)
+				return
+			}
+			s.serveCollection(child, parts[1], parts[3:], ctx)
+			return
+		}
+	}
+
+	s.serveCollection(c, "", parts[1:], ctx)
+}
+
+func (s *Server) serveCollection(c *collection, parentID string, ids []string, ctx *requestContext) {
+	w, r := ctx.w, ctx.r
+
+	if len(ids) == 0 || ids[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			query := r.URL.Query()
+			if c.parentField != "" {
+				query.Set(c.parentField, parentID)
+			}
+			// The SDK lists subnets by ?vcn= rather than ?vcnId=.
+			if vcn := query.Get("vcn"); vcn != "" && query.Get("vcnId") == "" {
+				query.Set("vcnId", vcn)
+			}
+			res, next := c.list(query)
+			if next != "" {
+				w.Header().Set("opc-next-page", next)
+			}
+			list := make([]interface{}, len(res))
+			for i, rec := range res {
+				// Some resources (API keys, ...) can only be polled by listing.
+				rec.advance()
+				list[i] = rec.fields
+			}
+			writeJSON(w, http.StatusOK, list)
+		case http.MethodPost:
+			if c.readOnly {
+				writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+				return
+			}
+			token := r.Header.Get("opc-retry-token")
+			if prev, ok := s.retries[c.name+"/"+token]; ok && token != "" {
+				if prev.raw != string(ctx.raw) {
+					writeError(w, http.StatusConflict, "InvalidatedRetryToken", "The retry token was used for a different request")
+					return
+				}
+				writeRecord(w, http.StatusOK, prev.rec)
+				return
+			}
+			rec := newRecord(ctx.body)
+			if c.key() == "id" {
+				region := ctx.region
+				if c.global {
+					region = ""
+				}
+				rec.fields["id"] = newOCID(c.kind, region)
+			}
+			if c.parentField != "" {
+				rec.fields[c.parentField] = parentID
+			}
+			rec.fields["timeCreated"] = now()
+			if _, ok := rec.fields["displayName"]; !ok && !c.global && c.kind != "" {
+				// The API generates a display name when none is given.
+				rec.fields["displayName"] = c.kind + time.Now().UTC().Format("20060102150405")
+			}
+			rec.transition(c.created)
+			if c.onCreate != nil {
+				c.onCreate(s, ctx, rec)
+			}
+			c.add(rec)
+			if token != "" {
+				s.retries[c.name+"/"+token] = retriedCreate{raw: string(ctx.raw), rec: rec}
+			}
+			writeRecord(w, http.StatusOK, rec)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+		}
+		return
+	}
+
+	rec, ok := c.get(ids[0])
+	if !ok || (c.parentField != "" && rec.str(c.parentField) != parentID) {
+		writeNotFound(w)
+		return
+	}
+
+	if len(ids) > 1 {
+		if h, ok := subResourceHandlers[c.name+"/"+ids[1]]; ok {
+			h(s, ctx, rec)
+			return
+		}
+		writeNotFound(w)
+		return
+	}
+
+	// Updates and deletes that give an If-Match header only apply to the
+	// record as it was when that ETag was returned.
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && r.Method != http.MethodGet && ifMatch != rec.etag() {
+		writeError(w, http.StatusPreconditionFailed, "NoEtagMatch", "The resource has changed since the If-Match ETag was returned")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		rec.advance()
+		writeRecord(w, http.StatusOK, rec)
+	case http.MethodPut:
+		rec.merge(ctx.body)
+		rec.transition(c.updated)
+		writeRecord(w, http.StatusOK, rec)
+	case http.MethodPost:
+		action := r.URL.Query().Get("action")
+		states, ok := c.actions[action]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("Unsupported action %q", action))
+			return
+		}
+		rec.transition(states)
+		writeRecord(w, http.StatusOK, rec)
+	case http.MethodDelete:
+		if len(c.deleted) == 0 {
+			c.remove(ids[0])
+		} else {
+			rec.transition(c.deleted)
+		}
+		if c.onDelete != nil {
+			c.onDelete(s, rec)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "Method not allowed")
+	}
+}
+
+// subResourceHandlers serve the odd endpoints hanging off a single record,
+// keyed by "{collection}/{segment}".
+var subResourceHandlers = map[string]func(s *Server, ctx *requestContext, r *record){}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// Like the real API, no trailing newline; GetNamespace depends on it.
+	body, _ := json.Marshal(v)
+	w.Write(body)
+}
+
+func writeRecord(w http.ResponseWriter, status int, r *record) {
+	w.Header().Set("ETag", r.etag())
+	writeJSON(w, status, r.fields)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{
+		"code":    code,
+		"message": message,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "NotAuthorizedOrNotFound", "Authorization failed or requested resource not found")
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func newOCID(kind, region string) string {
+	return fmt.Sprintf("ocid1.%s.oc1.%s.%s", kind, region, randomHex(16))
+}
+
+func newRequestID() string {
+	return strings.ToUpper(randomHex(16))
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
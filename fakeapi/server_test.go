@@ -0,0 +1,239 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	Server *Server
+	Client *baremetal.Client
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.Server = NewServer()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	s.Require().NoError(err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	s.Client, err = baremetal.NewClient(
+		"ocid1.user.oc1..fakeuser",
+		"ocid1.tenancy.oc1..faketenancy",
+		"b4:8a:7d:54:e6:81:04:b2:fa:ce:ba:55:34:dd:00:00",
+		baremetal.PrivateKeyBytes(pemKey),
+		baremetal.UrlTemplate(s.Server.URLTemplate()),
+		baremetal.CustomTransport(&http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *ServerTestSuite) TearDownTest() {
+	s.Server.Close()
+}
+
+func (s *ServerTestSuite) TestRejectsUnsignedRequests() {
+	res, err := http.Get(s.Server.URL())
+	if err == nil {
+		res.Body.Close()
+	}
+	// The test server's certificate is self signed.
+	s.Error(err)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	res, err = client.Get(s.Server.URL() + "/iaas/us-phoenix-1/20160918/shapes")
+	s.Require().NoError(err)
+	res.Body.Close()
+	s.Equal(http.StatusUnauthorized, res.StatusCode)
+}
+
+func (s *ServerTestSuite) TestInstanceLifecycle() {
+	ads, err := s.Client.ListAvailabilityDomains("compartment_id")
+	s.Require().NoError(err)
+	s.Len(ads.AvailabilityDomains, len(AvailabilityDomains))
+
+	vcn, err := s.Client.CreateVirtualNetwork("10.0.0.0/16", "compartment_id", nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceProvisioning, vcn.State)
+	s.NotEmpty(vcn.DefaultRouteTableID)
+
+	subnet, err := s.Client.CreateSubnet(AvailabilityDomains[0], "10.0.1.0/24", "compartment_id", vcn.ID, nil)
+	s.Require().NoError(err)
+
+	inst, err := s.Client.LaunchInstance(AvailabilityDomains[0], "compartment_id", "ocid1.image.oc1.phx.platformimage0", Shapes[0], subnet.ID, nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceProvisioning, inst.State)
+
+	inst, err = s.Client.GetInstance(inst.ID)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceRunning, inst.State)
+
+	attachments, err := s.Client.ListVnicAttachments("compartment_id", &baremetal.ListVnicAttachmentsOptions{InstanceIDListOptions: baremetal.InstanceIDListOptions{InstanceID: inst.ID}})
+	s.Require().NoError(err)
+	s.Require().Len(attachments.Attachments, 1)
+
+	vnic, err := s.Client.GetVnic(attachments.Attachments[0].VnicID)
+	s.Require().NoError(err)
+	s.NotEmpty(vnic.PrivateIPAddress)
+	s.Equal(subnet.ID, vnic.SubnetID)
+
+	s.Require().NoError(s.Client.TerminateInstance(inst.ID, nil))
+	inst, err = s.Client.GetInstance(inst.ID)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceTerminated, inst.State)
+}
+
+func (s *ServerTestSuite) TestIfMatch() {
+	cpe, err := s.Client.CreateCpe("compartment_id", "120.90.41.18", nil)
+	s.Require().NoError(err)
+	s.NotEmpty(cpe.ETag)
+
+	opts := &baremetal.IfMatchDisplayNameOptions{}
+	opts.DisplayName = "changed"
+	opts.IfMatch = cpe.ETag
+	updated, err := s.Client.UpdateCpe(cpe.ID, opts)
+	s.Require().NoError(err)
+	s.NotEqual(cpe.ETag, updated.ETag)
+
+	// The first ETag is stale now.
+	err = s.Client.DeleteCpe(cpe.ID, &baremetal.IfMatchOptions{IfMatch: cpe.ETag})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NoEtagMatch")
+
+	s.NoError(s.Client.DeleteCpe(cpe.ID, &baremetal.IfMatchOptions{IfMatch: updated.ETag}))
+}
+
+func (s *ServerTestSuite) TestRetryToken() {
+	opts := &baremetal.CreateOptions{}
+	opts.RetryToken = "token"
+	opts.DisplayName = "first"
+	cpe, err := s.Client.CreateCpe("compartment_id", "120.90.41.18", opts)
+	s.Require().NoError(err)
+
+	// A retry of the same create gets the same CPE back.
+	retried, err := s.Client.CreateCpe("compartment_id", "120.90.41.18", opts)
+	s.Require().NoError(err)
+	s.Equal(cpe.ID, retried.ID)
+
+	list, err := s.Client.ListCpes("compartment_id", nil)
+	s.Require().NoError(err)
+	s.Len(list.Cpes, 1)
+
+	// The token can't be reused for a different create.
+	opts.DisplayName = "second"
+	_, err = s.Client.CreateCpe("compartment_id", "120.90.41.18", opts)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "InvalidatedRetryToken")
+}
+
+func (s *ServerTestSuite) TestFailedDBSystemLaunch() {
+	sys, err := s.Client.LaunchDBSystem("AD-1", "compartment_id", "BM.DenseIO1.36", "subnet_id", []string{"key"}, 72, nil)
+	s.Require().NoError(err)
+	s.Equal("PROVISIONING", sys.State)
+
+	sys, err = s.Client.GetDBSystem(sys.ID)
+	s.Require().NoError(err)
+	s.Equal("FAILED", sys.State)
+	s.Equal("Shape BM.DenseIO1.36 has 36 cores available, 72 were requested", sys.LifecycleDetails)
+}
+
+func (s *ServerTestSuite) TestNotFound() {
+	_, err := s.Client.GetInstance("ocid1.instance.oc1.phx.missing")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "NotAuthorizedOrNotFound")
+}
+
+func (s *ServerTestSuite) TestLoadBalancerWorkRequests() {
+	workReqID, err := s.Client.CreateLoadBalancer(nil, nil, "compartment_id", nil, LoadBalancerShapes[0], []string{"subnet1", "subnet2"}, nil)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(workReqID)
+
+	workReq, err := s.Client.GetWorkRequest(workReqID, nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.WorkRequestInProgress, workReq.State)
+
+	workReq, err = s.Client.GetWorkRequest(workReqID, nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.WorkRequestSucceeded, workReq.State)
+
+	lb, err := s.Client.GetLoadBalancer(workReq.LoadBalancerID, nil)
+	s.Require().NoError(err)
+	s.Equal(baremetal.ResourceActive, lb.State)
+	s.NotEmpty(lb.IPAddresses)
+
+	workReqID, err = s.Client.CreateBackendSet(lb.ID, "backendset", "ROUND_ROBIN", nil, &baremetal.HealthChecker{Protocol: "HTTP", URLPath: "/"}, nil, nil)
+	s.Require().NoError(err)
+
+	// Not applied until the work request has succeeded.
+	_, err = s.Client.GetBackendSet(lb.ID, "backendset", nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "has no backend set named backendset")
+
+	for i := 0; i < 2; i++ {
+		workReq, err = s.Client.GetWorkRequest(workReqID, nil)
+		s.Require().NoError(err)
+	}
+	s.Equal(baremetal.WorkRequestSucceeded, workReq.State)
+	s.Equal("CreateBackendSet", workReq.Type)
+
+	set, err := s.Client.GetBackendSet(lb.ID, "backendset", nil)
+	s.Require().NoError(err)
+	s.Equal("ROUND_ROBIN", set.Policy)
+
+	// A listener using a certificate the load balancer doesn't have fails.
+	workReqID, err = s.Client.CreateListener(lb.ID, "http", "backendset", "HTTP", 443, &baremetal.SSLConfiguration{CertificateName: "missing"}, nil)
+	s.Require().NoError(err)
+	for i := 0; i < 2; i++ {
+		workReq, err = s.Client.GetWorkRequest(workReqID, nil)
+		s.Require().NoError(err)
+	}
+	s.Equal(baremetal.WorkRequestFailed, workReq.State)
+	s.Require().Len(workReq.ErrorDetails, 1)
+	s.Contains(workReq.ErrorDetails[0].Message, "no certificate named missing")
+
+	workReqs, err := s.Client.ListWorkRequests(lb.ID, nil)
+	s.Require().NoError(err)
+	s.Len(workReqs.WorkRequests, 3)
+}
+
+func (s *ServerTestSuite) TestObjectStorage() {
+	namespace, err := s.Client.GetNamespace()
+	s.Require().NoError(err)
+	s.Equal(DefaultNamespace, string(*namespace))
+
+	_, err = s.Client.CreateBucket("compartment_id", "bucket", *namespace, nil)
+	s.Require().NoError(err)
+
+	opts := &baremetal.PutObjectOptions{Metadata: map[string]string{"foo": "bar"}}
+	_, err = s.Client.PutObject(*namespace, "bucket", "dir/object", []byte("content"), opts)
+	s.Require().NoError(err)
+
+	obj, err := s.Client.GetObject(*namespace, "bucket", "dir/object", nil)
+	s.Require().NoError(err)
+	s.Equal("content", string(obj.Body))
+	s.Equal("bar", obj.Metadata["foo"])
+
+	list, err := s.Client.ListObjects(*namespace, "bucket", &baremetal.ListObjectsOptions{Prefix: "dir/"})
+	s.Require().NoError(err)
+	s.Require().Len(list.Objects, 1)
+	s.Equal("dir/object", list.Objects[0].Name)
+
+	_, err = s.Client.DeleteObject(*namespace, "bucket", "dir/object", nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.Client.DeleteBucket("bucket", *namespace, nil))
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package fakeapi
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// record is a single stored API resource. Its fields are kept as decoded
+// JSON so that whatever the client sends is echoed back on reads.
+type record struct {
+	fields map[string]interface{}
+	// pending holds the lifecycle states the record still has to move
+	// through. One state is consumed every time the record is read.
+	pending []string
+}
+
+func newRecord(fields map[string]interface{}) *record {
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	return &record{fields: fields}
+}
+
+// transition sets the current lifecycle state to the first of states and
+// queues the rest.
+func (r *record) transition(states []string) {
+	if len(states) == 0 {
+		return
+	}
+	r.fields["lifecycleState"] = states[0]
+	r.pending = append([]string{}, states[1:]...)
+}
+
+// advance moves the record one step further through its pending states.
+func (r *record) advance() {
+	if len(r.pending) == 0 {
+		return
+	}
+	r.fields["lifecycleState"] = r.pending[0]
+	r.pending = r.pending[1:]
+}
+
+func (r *record) state() string {
+	s, _ := r.fields["lifecycleState"].(string)
+	return s
+}
+
+func (r *record) str(key string) string {
+	s, _ := r.fields[key].(string)
+	return s
+}
+
+// etag is derived from the record content so it changes whenever the
+// record does.
+func (r *record) etag() string {
+	buf, _ := json.Marshal(r.fields)
+	return fmt.Sprintf("%x", md5.Sum(buf))
+}
+
+// merge copies every key of update onto the record.
+func (r *record) merge(update map[string]interface{}) {
+	for k, v := range update {
+		r.fields[k] = v
+	}
+}
+
+// matches reports whether the record satisfies the list filters in query.
+// Filters naming a field the record does not have are ignored, which lets
+// shared records such as platform images show up in every compartment.
+func (r *record) matches(query url.Values) bool {
+	for key, vals := range query {
+		if ignoredQueryParams[key] || len(vals) == 0 {
+			continue
+		}
+		v, ok := r.fields[key]
+		if !ok || v == nil {
+			continue
+		}
+		if fmt.Sprint(v) != vals[0] {
+			return false
+		}
+	}
+	return true
+}
+
+var ignoredQueryParams = map[string]bool{
+	"limit":     true,
+	"page":      true,
+	"sortBy":    true,
+	"sortOrder": true,
+}
+
+// collection is an ordered set of records of one resource type.
+type collection struct {
+	// name is the URL segment the collection is served under.
+	name string
+	// kind is the resource type used when minting OCIDs.
+	kind string
+	// idField is the field records are keyed by, "id" unless set.
+	idField string
+	// global collections mint OCIDs without a region, as identity does.
+	global bool
+	// parentField is set for collections nested under another resource,
+	// such as users/{userId}/apiKeys.
+	parentField string
+	// readOnly collections only hold data seeded by the server.
+	readOnly bool
+	// created lists the lifecycle states a new record moves through.
+	created []string
+	// deleted lists the lifecycle states a deleted record moves through.
+	// Records of collections without them are removed immediately.
+	deleted []string
+	// updated lists the lifecycle states an updated record moves through.
+	updated []string
+	// actions maps an ?action= value to the lifecycle states it causes.
+	actions map[string][]string
+	// onCreate fills in server generated fields of a new record.
+	onCreate func(s *Server, ctx *requestContext, r *record)
+	// onDelete runs after a record has been deleted.
+	onDelete func(s *Server, r *record)
+
+	records map[string]*record
+	order   []string
+}
+
+func (c *collection) key() string {
+	if c.idField == "" {
+		return "id"
+	}
+	return c.idField
+}
+
+func (c *collection) add(r *record) {
+	if c.records == nil {
+		c.records = map[string]*record{}
+	}
+	id := r.str(c.key())
+	if _, ok := c.records[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.records[id] = r
+}
+
+func (c *collection) get(id string) (*record, bool) {
+	r, ok := c.records[id]
+	return r, ok
+}
+
+func (c *collection) remove(id string) {
+	delete(c.records, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the records matching query, honouring limit/page paging.
+// The returned page token is empty on the last page.
+func (c *collection) list(query url.Values) (res []*record, next string) {
+	var all []*record
+	for _, id := range c.order {
+		if r := c.records[id]; r.matches(query) {
+			all = append(all, r)
+		}
+	}
+
+	start, _ := strconv.Atoi(query.Get("page"))
+	if start > len(all) {
+		start = len(all)
+	}
+	end := len(all)
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && start+limit < end {
+		end = start + limit
+		next = strconv.Itoa(end)
+	}
+	return all[start:end], next
+}
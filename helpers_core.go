@@ -0,0 +1,501 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/mutexkv"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
+)
+
+// volumeBackupMap flattens a volume backup into the attributes of
+// VolumeBackupResource.
+func volumeBackupMap(v baremetal.VolumeBackup) map[string]interface{} {
+	return map[string]interface{}{
+		"compartment_id":        v.CompartmentID,
+		"display_name":          v.DisplayName,
+		"id":                    v.ID,
+		"state":                 v.State,
+		"size_in_mbs":           v.SizeInMBs,
+		"time_created":          v.TimeCreated.String(),
+		"time_request_received": v.TimeRequestReceived.String(),
+		"unique_size_in_mbs":    v.UniqueSizeInMBs,
+		"volume_id":             v.VolumeID,
+	}
+}
+
+// imageMap flattens an image into the attributes of the images data source.
+func imageMap(v baremetal.Image) map[string]interface{} {
+	return map[string]interface{}{
+		"base_image_id":            v.BaseImageID,
+		"compartment_id":           v.CompartmentID,
+		"create_image_allowed":     v.CreateImageAllowed,
+		"display_name":             v.DisplayName,
+		"id":                       v.ID,
+		"state":                    v.State,
+		"operating_system":         v.OperatingSystem,
+		"operating_system_version": v.OperatingSystemVersion,
+		"time_created":             v.TimeCreated.String(),
+	}
+}
+
+// subnetMap flattens a subnet into the attributes of the subnets data source.
+func subnetMap(v baremetal.Subnet) map[string]interface{} {
+	return map[string]interface{}{
+		"availability_domain":        v.AvailabilityDomain,
+		"cidr_block":                 v.CIDRBlock,
+		"compartment_id":             v.CompartmentID,
+		"route_table_id":             v.RouteTableID,
+		"vcn_id":                     v.VcnID,
+		"security_list_ids":          v.SecurityListIDs,
+		"display_name":               v.DisplayName,
+		"id":                         v.ID,
+		"prohibit_public_ip_on_vnic": v.ProhibitPublicIpOnVnic,
+		"state":                      v.State,
+		"time_created":               v.TimeCreated.String(),
+		"virtual_router_ip":          v.VirtualRouterIP,
+		"virtual_router_mac":         v.VirtualRouterMac,
+	}
+}
+
+// policyBackups lists the backups of a volume that a backup policy manages,
+// the ones named with its prefix, newest first. Backups that are going away
+// or faulty don't count towards the policy.
+func policyBackups(c client.BareMetalClient, compartmentID, volumeID, prefix string) (backups []baremetal.VolumeBackup, e error) {
+	opts := &baremetal.ListBackupsOptions{VolumeID: volumeID}
+	for {
+		var list *baremetal.ListVolumeBackups
+		if list, e = c.ListVolumeBackups(compartmentID, opts); e != nil {
+			return
+		}
+
+		for _, b := range list.VolumeBackups {
+			switch b.State {
+			case baremetal.ResourceTerminating, baremetal.ResourceTerminated, baremetal.ResourceFaulty:
+				continue
+			}
+			if b.VolumeID == volumeID && strings.HasPrefix(b.DisplayName, prefix) {
+				backups = append(backups, b)
+			}
+		}
+
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &opts.ListOptions.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].TimeCreated.After(backups[j].TimeCreated.Time)
+	})
+	return
+}
+
+// expireBackups splits backups, newest first, into the retentionCount newest
+// ones and the expired rest.
+func expireBackups(backups []baremetal.VolumeBackup, retentionCount int) (retained, expired []baremetal.VolumeBackup) {
+	if len(backups) <= retentionCount {
+		return backups, nil
+	}
+	return backups[:retentionCount], backups[retentionCount:]
+}
+
+// validateSchedule checks that a backup policy's schedule is a duration
+// longer than zero, so that backups aren't due all the time.
+func validateSchedule(v interface{}, k string) (ws []string, es []error) {
+	schedule, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return validateDuration(v, k)
+	}
+	if schedule <= 0 {
+		es = append(es, fmt.Errorf("%q must be longer than 0s, got %s", k, v))
+	}
+	return
+}
+
+// nextBackupTime is when a policy taking a backup every schedule is due to
+// take the next one. Without backups, one is due right away.
+func nextBackupTime(backups []baremetal.VolumeBackup, schedule time.Duration, now time.Time) time.Time {
+	if len(backups) == 0 {
+		return now
+	}
+	return backups[0].TimeCreated.Add(schedule)
+}
+
+// iscsiCommands are the iscsiadm commands that log an instance in to, and out
+// of, the target of an iSCSI volume attachment, authenticating with CHAP when
+// the attachment has CHAP credentials. Login also makes the node start
+// automatically so the volume comes back after a reboot.
+func iscsiCommands(a *baremetal.VolumeAttachment) (login, logout []string) {
+	node := fmt.Sprintf("sudo iscsiadm -m node -T %s -p %s:%d", a.IQN, a.IPv4, a.Port)
+	update := fmt.Sprintf("sudo iscsiadm -m node -o update -T %s -n", a.IQN)
+
+	login = []string{fmt.Sprintf("sudo iscsiadm -m node -o new -T %s -p %s:%d", a.IQN, a.IPv4, a.Port)}
+	if a.CHAPUsername != "" {
+		login = append(login,
+			fmt.Sprintf("%s node.session.auth.authmethod -v CHAP", update),
+			fmt.Sprintf("%s node.session.auth.username -v %s", update, a.CHAPUsername),
+			fmt.Sprintf("%s node.session.auth.password -v %s", update, a.CHAPSecret),
+		)
+	}
+	login = append(login,
+		fmt.Sprintf("%s node.startup -v automatic", update),
+		node+" -l",
+	)
+
+	logout = []string{
+		node + " -u",
+		fmt.Sprintf("sudo iscsiadm -m node -o delete -T %s -p %s:%d", a.IQN, a.IPv4, a.Port),
+	}
+	return
+}
+
+// securityListMutexes serializes the changes to each security list made by
+// the resources of one Terraform run.
+var securityListMutexes = mutexkv.NewMutexKV()
+
+// securityListUpdateAttempts is how many times a security list is read and
+// written back before giving up on changes made elsewhere in between.
+const securityListUpdateAttempts = 5
+
+// updateSecurityListRules reads a security list, lets modify change its rules
+// and writes them back. With the provider's use_etags setting, the write only
+// applies if no one else changed the list since it was read, and otherwise
+// starts over. When ifMatch is given, the write instead only applies if the
+// list is still as it was when that ETag was returned, and isn't retried.
+func updateSecurityListRules(c client.BareMetalClient, id, ifMatch string, modify func(*baremetal.SecurityList) error) (res *baremetal.SecurityList, e error) {
+	securityListMutexes.Lock(id)
+	defer securityListMutexes.Unlock(id)
+
+	useETags := crud.SettingsOf(c).UseETags
+	for attempt := 1; ; attempt++ {
+		var list *baremetal.SecurityList
+		if list, e = c.GetSecurityList(id); e != nil {
+			return
+		}
+		if e = modify(list); e != nil {
+			return
+		}
+
+		opts := &baremetal.UpdateSecurityListOptions{
+			EgressRules:  list.EgressSecurityRules,
+			IngressRules: list.IngressSecurityRules,
+		}
+		if opts.EgressRules == nil {
+			opts.EgressRules = []baremetal.EgressSecurityRule{}
+		}
+		if opts.IngressRules == nil {
+			opts.IngressRules = []baremetal.IngressSecurityRule{}
+		}
+		retry := false
+		switch {
+		case ifMatch != "":
+			opts.IfMatch = ifMatch
+		case useETags:
+			opts.IfMatch = list.ETag
+			retry = attempt < securityListUpdateAttempts
+		}
+
+		res, e = c.UpdateSecurityList(id, opts)
+		if !retry || !crud.IsPreconditionFailed(e) {
+			return
+		}
+	}
+}
+
+// securityRuleKey identifies a security rule by everything it matches on.
+func securityRuleKey(direction, cidr, protocol string, icmp *baremetal.ICMPOptions, tcp *baremetal.TCPOptions, udp *baremetal.UDPOptions, stateless bool) string {
+	key := fmt.Sprintf("%s %s %s stateless=%t", direction, cidr, protocol, stateless)
+	if icmp != nil {
+		key += fmt.Sprintf(" icmp=%d/%d", icmp.Type, icmp.Code)
+	}
+	if tcp != nil {
+		key += fmt.Sprintf(" tcp=%d-%d", tcp.DestinationPortRange.Min, tcp.DestinationPortRange.Max)
+	}
+	if udp != nil {
+		key += fmt.Sprintf(" udp=%d-%d", udp.DestinationPortRange.Min, udp.DestinationPortRange.Max)
+	}
+	return key
+}
+
+func egressRuleKey(r baremetal.EgressSecurityRule) string {
+	return securityRuleKey("egress", r.Destination, r.Protocol, r.ICMPOptions, r.TCPOptions, r.UDPOptions, r.IsStateless)
+}
+
+func ingressRuleKey(r baremetal.IngressSecurityRule) string {
+	return securityRuleKey("ingress", r.Source, r.Protocol, r.ICMPOptions, r.TCPOptions, r.UDPOptions, r.IsStateless)
+}
+
+func egressRuleKeys(rules []baremetal.EgressSecurityRule) map[string]bool {
+	keys := map[string]bool{}
+	for _, r := range rules {
+		keys[egressRuleKey(r)] = true
+	}
+	return keys
+}
+
+func ingressRuleKeys(rules []baremetal.IngressSecurityRule) map[string]bool {
+	keys := map[string]bool{}
+	for _, r := range rules {
+		keys[ingressRuleKey(r)] = true
+	}
+	return keys
+}
+
+// egressRuleHash and ingressRuleHash key the security rules of a security
+// list by everything they match on, so the order the API returns them in
+// doesn't matter.
+func egressRuleHash(v interface{}) int {
+	return hashcode.String(egressRuleKey(buildEgressRules([]interface{}{v})[0]))
+}
+
+func ingressRuleHash(v interface{}) int {
+	return hashcode.String(ingressRuleKey(buildIngressRules([]interface{}{v})[0]))
+}
+
+func routeRuleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	return hashcode.String(fmt.Sprintf("%s %s", m["cidr_block"], m["network_entity_id"]))
+}
+
+// migrateListToSet moves the elements of a list attribute of flattened state
+// to the indexes of the set that replaced it. hash gets the attributes of an
+// element, relative to the element.
+func migrateListToSet(attributes map[string]string, name string, hash func(map[string]string) int) {
+	prefix := name + "."
+	elems := map[string]map[string]string{}
+	for k, v := range attributes {
+		if !strings.HasPrefix(k, prefix) || k == prefix+"#" {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(k, prefix), ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if elems[parts[0]] == nil {
+			elems[parts[0]] = map[string]string{}
+		}
+		elems[parts[0]][parts[1]] = v
+		delete(attributes, k)
+	}
+
+	// Equal elements collapse into one.
+	codes := map[int]bool{}
+	for _, elem := range elems {
+		code := hash(elem)
+		codes[code] = true
+		for k, v := range elem {
+			attributes[fmt.Sprintf("%s%d.%s", prefix, code, k)] = v
+		}
+	}
+	if _, ok := attributes[prefix+"#"]; ok || len(codes) > 0 {
+		attributes[prefix+"#"] = strconv.Itoa(len(codes))
+	}
+}
+
+// flatSecurityRule turns the flattened attributes of a security rule back
+// into its configuration.
+func flatSecurityRule(flat map[string]string) map[string]interface{} {
+	conf := map[string]interface{}{
+		"destination": flat["destination"],
+		"protocol":    flat["protocol"],
+		"source":      flat["source"],
+		"stateless":   flat["stateless"] == "true",
+	}
+	for _, name := range []string{"icmp_options", "tcp_options", "udp_options"} {
+		opts := []interface{}{}
+		if flat[name+".#"] == "1" {
+			confOpts := map[string]interface{}{}
+			for _, field := range []string{"code", "type", "min", "max"} {
+				if v, ok := flat[name+".0."+field]; ok {
+					confOpts[field], _ = strconv.Atoi(v)
+				}
+			}
+			opts = append(opts, confOpts)
+		}
+		conf[name] = opts
+	}
+	return conf
+}
+
+// securityRuleProtocols are the protocol names security rules accept besides
+// protocol numbers and "all".
+var securityRuleProtocols = map[string]string{
+	"icmp":   "1",
+	"tcp":    "6",
+	"udp":    "17",
+	"icmpv6": "58",
+}
+
+// sensitivePorts shouldn't be open to the whole internet.
+var sensitivePorts = []struct {
+	port    uint64
+	service string
+}{
+	{22, "SSH"},
+	{3389, "RDP"},
+}
+
+func validateProtocol(v interface{}, k string) (ws []string, es []error) {
+	protocol := strings.ToLower(v.(string))
+	if _, ok := securityRuleProtocols[protocol]; ok || protocol == "all" {
+		return
+	}
+	if n, err := strconv.Atoi(protocol); err == nil && n >= 0 && n <= 255 {
+		return
+	}
+	es = append(es, fmt.Errorf("%q must be a protocol number from 0 to 255, all, icmp, icmpv6, tcp or udp, got %q", k, v))
+	return
+}
+
+// normalizeProtocol turns protocol names into the numbers the API uses.
+func normalizeProtocol(v interface{}) string {
+	protocol := strings.ToLower(v.(string))
+	if n, ok := securityRuleProtocols[protocol]; ok {
+		return n
+	}
+	return protocol
+}
+
+// securityRule is what egress and ingress security rules have in common.
+type securityRule struct {
+	direction string
+	cidr      string
+	protocol  string
+	icmp      *baremetal.ICMPOptions
+	tcp       *baremetal.PortRange
+	udp       *baremetal.PortRange
+	stateless bool
+	key       string
+}
+
+func securityRules(egress []baremetal.EgressSecurityRule, ingress []baremetal.IngressSecurityRule) (rules []securityRule) {
+	for _, r := range egress {
+		rule := securityRule{direction: "egress", cidr: r.Destination, protocol: r.Protocol, icmp: r.ICMPOptions, stateless: r.IsStateless, key: egressRuleKey(r)}
+		if r.TCPOptions != nil {
+			rule.tcp = &r.TCPOptions.DestinationPortRange
+		}
+		if r.UDPOptions != nil {
+			rule.udp = &r.UDPOptions.DestinationPortRange
+		}
+		rules = append(rules, rule)
+	}
+	for _, r := range ingress {
+		rule := securityRule{direction: "ingress", cidr: r.Source, protocol: r.Protocol, icmp: r.ICMPOptions, stateless: r.IsStateless, key: ingressRuleKey(r)}
+		if r.TCPOptions != nil {
+			rule.tcp = &r.TCPOptions.DestinationPortRange
+		}
+		if r.UDPOptions != nil {
+			rule.udp = &r.UDPOptions.DestinationPortRange
+		}
+		rules = append(rules, rule)
+	}
+	return
+}
+
+// ports is the port range of a TCP or UDP rule, nil for all ports.
+func (r securityRule) ports() *baremetal.PortRange {
+	if r.tcp != nil {
+		return r.tcp
+	}
+	return r.udp
+}
+
+// checkSecurityRules finds the problems with security rules that validating
+// each of their attributes can't.
+func checkSecurityRules(egress []baremetal.EgressSecurityRule, ingress []baremetal.IngressSecurityRule) error {
+	problems := []string{}
+	check := func(r securityRule, options string, protocol string, given bool) {
+		if given && r.protocol != securityRuleProtocols[protocol] {
+			problems = append(problems, fmt.Sprintf("%s rule %s has %s but its protocol isn't %s", r.direction, r.key, options, protocol))
+		}
+	}
+	for _, r := range securityRules(egress, ingress) {
+		check(r, "icmp_options", "icmp", r.icmp != nil)
+		check(r, "tcp_options", "tcp", r.tcp != nil)
+		check(r, "udp_options", "udp", r.udp != nil)
+		if ports := r.ports(); ports != nil && ports.Min > ports.Max {
+			problems = append(problems, fmt.Sprintf("%s rule %s has a port range from %d down to %d, min can't be more than max", r.direction, r.key, ports.Min, ports.Max))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid security rules:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// covers tells whether every packet a matches is also matched by r.
+func (r securityRule) covers(a securityRule) bool {
+	if r.direction != a.direction || r.stateless != a.stateless {
+		return false
+	}
+	_, rNet, err := net.ParseCIDR(r.cidr)
+	if err != nil {
+		return false
+	}
+	_, aNet, err := net.ParseCIDR(a.cidr)
+	if err != nil {
+		return false
+	}
+	rBits, _ := rNet.Mask.Size()
+	aBits, _ := aNet.Mask.Size()
+	if rBits > aBits || !rNet.Contains(aNet.IP) {
+		return false
+	}
+
+	switch {
+	case r.protocol == "all":
+		return true
+	case r.protocol != a.protocol:
+		return false
+	case r.icmp != nil:
+		return a.icmp != nil && *r.icmp == *a.icmp
+	case r.ports() != nil:
+		rPorts, aPorts := r.ports(), a.ports()
+		return aPorts != nil && rPorts.Min <= aPorts.Min && aPorts.Max <= rPorts.Max
+	}
+	return true
+}
+
+// opens tells whether r lets the given TCP port in.
+func (r securityRule) opens(port uint64) bool {
+	if r.protocol != "all" && r.protocol != securityRuleProtocols["tcp"] {
+		return false
+	}
+	ports := r.ports()
+	return ports == nil || (ports.Min <= port && port <= ports.Max)
+}
+
+// lintSecurityRules warns about rules that are shadowed by other rules, and
+// about ingress rules that open sensitive ports to the internet. Rules are
+// sets keyed like securityRule.key, so there are no duplicates to warn about.
+func lintSecurityRules(egress []baremetal.EgressSecurityRule, ingress []baremetal.IngressSecurityRule) (warnings []string) {
+	rules := securityRules(egress, ingress)
+	for i, r := range rules {
+		for j, other := range rules {
+			if i != j && other.covers(r) {
+				warnings = append(warnings, fmt.Sprintf("%s rule %s is shadowed by %s", r.direction, r.key, other.key))
+			}
+		}
+
+		if r.direction != "ingress" || r.cidr != "0.0.0.0/0" {
+			continue
+		}
+		for _, p := range sensitivePorts {
+			if r.opens(p.port) {
+				warnings = append(warnings, fmt.Sprintf("ingress rule %s opens port %d (%s) to 0.0.0.0/0", r.key, p.port, p.service))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+type VolumeBackupPolicyHelpersTestSuite struct {
+	suite.Suite
+	Now     time.Time
+	Backups []baremetal.VolumeBackup
+}
+
+func (s *VolumeBackupPolicyHelpersTestSuite) SetupTest() {
+	s.Now = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.Backups = []baremetal.VolumeBackup{
+		{ID: "newest", TimeCreated: baremetal.Time{Time: s.Now.Add(-time.Hour)}},
+		{ID: "middle", TimeCreated: baremetal.Time{Time: s.Now.Add(-25 * time.Hour)}},
+		{ID: "oldest", TimeCreated: baremetal.Time{Time: s.Now.Add(-49 * time.Hour)}},
+	}
+}
+
+func (s *VolumeBackupPolicyHelpersTestSuite) TestExpireBackups() {
+	retained, expired := expireBackups(s.Backups, 2)
+	s.Equal(s.Backups[:2], retained)
+	s.Equal(s.Backups[2:], expired)
+
+	retained, expired = expireBackups(s.Backups, 3)
+	s.Equal(s.Backups, retained)
+	s.Empty(expired)
+}
+
+func (s *VolumeBackupPolicyHelpersTestSuite) TestNextBackupTime() {
+	s.Equal(s.Now.Add(23*time.Hour), nextBackupTime(s.Backups, 24*time.Hour, s.Now))
+	s.Equal(s.Now.Add(-30*time.Minute), nextBackupTime(s.Backups, 30*time.Minute, s.Now))
+	s.Equal(s.Now, nextBackupTime(nil, 24*time.Hour, s.Now))
+}
+
+func (s *VolumeBackupPolicyHelpersTestSuite) TestValidateSchedule() {
+	for schedule, valid := range map[string]bool{"24h": true, "1s": true, "0s": false, "-1h": false, "daily": false} {
+		_, errs := validateSchedule(schedule, "schedule")
+		s.Equal(valid, len(errs) == 0, schedule)
+	}
+}
+
+func TestVolumeBackupPolicyHelpersTestSuite(t *testing.T) {
+	suite.Run(t, new(VolumeBackupPolicyHelpersTestSuite))
+}
+
+type VolumeAttachmentHelpersTestSuite struct {
+	suite.Suite
+	Attachment *baremetal.VolumeAttachment
+}
+
+func (s *VolumeAttachmentHelpersTestSuite) SetupTest() {
+	s.Attachment = &baremetal.VolumeAttachment{
+		AttachmentType: baremetal.VolumeAttachmentISCSI,
+		IPv4:           "169.254.2.2",
+		IQN:            "iqn.2015-12.com.oracleiaas:abc",
+		Port:           3260,
+	}
+}
+
+func (s *VolumeAttachmentHelpersTestSuite) TestISCSICommands() {
+	login, logout := iscsiCommands(s.Attachment)
+	s.Equal([]string{
+		"sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.startup -v automatic",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -l",
+	}, login)
+	s.Equal([]string{
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -u",
+		"sudo iscsiadm -m node -o delete -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+	}, logout)
+}
+
+func (s *VolumeAttachmentHelpersTestSuite) TestISCSICommandsWithCHAP() {
+	s.Attachment.CHAPUsername = "user"
+	s.Attachment.CHAPSecret = "secret"
+	login, _ := iscsiCommands(s.Attachment)
+	s.Equal([]string{
+		"sudo iscsiadm -m node -o new -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.session.auth.authmethod -v CHAP",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.session.auth.username -v user",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.session.auth.password -v secret",
+		"sudo iscsiadm -m node -o update -T iqn.2015-12.com.oracleiaas:abc -n node.startup -v automatic",
+		"sudo iscsiadm -m node -T iqn.2015-12.com.oracleiaas:abc -p 169.254.2.2:3260 -l",
+	}, login)
+}
+
+func TestVolumeAttachmentHelpersTestSuite(t *testing.T) {
+	suite.Run(t, new(VolumeAttachmentHelpersTestSuite))
+}
+
+type RuleSetMigrationTestSuite struct {
+	suite.Suite
+}
+
+func (s *RuleSetMigrationTestSuite) TestMigrateSecurityListState() {
+	is := &terraform.InstanceState{
+		ID: "ocid1.securitylist.oc1.phx.aaaa",
+		Attributes: map[string]string{
+			"id":                                           "ocid1.securitylist.oc1.phx.aaaa",
+			"egress_security_rules.#":                      "1",
+			"egress_security_rules.0.destination":          "0.0.0.0/0",
+			"egress_security_rules.0.protocol":             "all",
+			"egress_security_rules.0.stateless":            "false",
+			"egress_security_rules.0.icmp_options.#":       "0",
+			"egress_security_rules.0.tcp_options.#":        "0",
+			"egress_security_rules.0.udp_options.#":        "0",
+			"ingress_security_rules.#":                     "2",
+			"ingress_security_rules.0.protocol":            "6",
+			"ingress_security_rules.0.source":              "0.0.0.0/0",
+			"ingress_security_rules.0.stateless":           "false",
+			"ingress_security_rules.0.icmp_options.#":      "0",
+			"ingress_security_rules.0.tcp_options.#":       "1",
+			"ingress_security_rules.0.tcp_options.0.max":   "80",
+			"ingress_security_rules.0.tcp_options.0.min":   "80",
+			"ingress_security_rules.0.udp_options.#":       "0",
+			"ingress_security_rules.1.protocol":            "1",
+			"ingress_security_rules.1.source":              "10.0.0.0/16",
+			"ingress_security_rules.1.stateless":           "true",
+			"ingress_security_rules.1.icmp_options.#":      "1",
+			"ingress_security_rules.1.icmp_options.0.code": "4",
+			"ingress_security_rules.1.icmp_options.0.type": "3",
+			"ingress_security_rules.1.tcp_options.#":       "0",
+			"ingress_security_rules.1.udp_options.#":       "0",
+		},
+	}
+
+	is, err := migrateSecurityListState(0, is, nil)
+	s.NoError(err)
+
+	egress := egressRuleHash(map[string]interface{}{"destination": "0.0.0.0/0", "protocol": "all"})
+	http := ingressRuleHash(map[string]interface{}{
+		"protocol":    "6",
+		"source":      "0.0.0.0/0",
+		"tcp_options": []interface{}{map[string]interface{}{"max": 80, "min": 80}},
+	})
+	icmp := ingressRuleHash(map[string]interface{}{
+		"protocol":     "1",
+		"source":       "10.0.0.0/16",
+		"stateless":    true,
+		"icmp_options": []interface{}{map[string]interface{}{"code": 4, "type": 3}},
+	})
+	s.Equal(map[string]string{
+		"id":                      "ocid1.securitylist.oc1.phx.aaaa",
+		"egress_security_rules.#": "1",
+		fmt.Sprintf("egress_security_rules.%d.destination", egress):        "0.0.0.0/0",
+		fmt.Sprintf("egress_security_rules.%d.protocol", egress):           "all",
+		fmt.Sprintf("egress_security_rules.%d.stateless", egress):          "false",
+		fmt.Sprintf("egress_security_rules.%d.icmp_options.#", egress):     "0",
+		fmt.Sprintf("egress_security_rules.%d.tcp_options.#", egress):      "0",
+		fmt.Sprintf("egress_security_rules.%d.udp_options.#", egress):      "0",
+		"ingress_security_rules.#":                                         "2",
+		fmt.Sprintf("ingress_security_rules.%d.protocol", http):            "6",
+		fmt.Sprintf("ingress_security_rules.%d.source", http):              "0.0.0.0/0",
+		fmt.Sprintf("ingress_security_rules.%d.stateless", http):           "false",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.#", http):      "0",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.#", http):       "1",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.max", http):   "80",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.min", http):   "80",
+		fmt.Sprintf("ingress_security_rules.%d.udp_options.#", http):       "0",
+		fmt.Sprintf("ingress_security_rules.%d.protocol", icmp):            "1",
+		fmt.Sprintf("ingress_security_rules.%d.source", icmp):              "10.0.0.0/16",
+		fmt.Sprintf("ingress_security_rules.%d.stateless", icmp):           "true",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.#", icmp):      "1",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.0.code", icmp): "4",
+		fmt.Sprintf("ingress_security_rules.%d.icmp_options.0.type", icmp): "3",
+		fmt.Sprintf("ingress_security_rules.%d.tcp_options.#", icmp):       "0",
+		fmt.Sprintf("ingress_security_rules.%d.udp_options.#", icmp):       "0",
+	}, is.Attributes)
+}
+
+func (s *RuleSetMigrationTestSuite) TestMigrateRouteTableState() {
+	is := &terraform.InstanceState{
+		ID: "ocid1.routetable.oc1.phx.aaaa",
+		Attributes: map[string]string{
+			"route_rules.#":                   "2",
+			"route_rules.0.cidr_block":        "0.0.0.0/0",
+			"route_rules.0.network_entity_id": "ocid1.internetgateway.oc1.phx.aaaa",
+			"route_rules.1.cidr_block":        "10.1.0.0/16",
+			"route_rules.1.network_entity_id": "ocid1.drg.oc1.phx.aaaa",
+		},
+	}
+
+	is, err := migrateRouteTableState(0, is, nil)
+	s.NoError(err)
+
+	ig := routeRuleHash(map[string]interface{}{"cidr_block": "0.0.0.0/0", "network_entity_id": "ocid1.internetgateway.oc1.phx.aaaa"})
+	drg := routeRuleHash(map[string]interface{}{"cidr_block": "10.1.0.0/16", "network_entity_id": "ocid1.drg.oc1.phx.aaaa"})
+	s.Equal(map[string]string{
+		"route_rules.#": "2",
+		fmt.Sprintf("route_rules.%d.cidr_block", ig):         "0.0.0.0/0",
+		fmt.Sprintf("route_rules.%d.network_entity_id", ig):  "ocid1.internetgateway.oc1.phx.aaaa",
+		fmt.Sprintf("route_rules.%d.cidr_block", drg):        "10.1.0.0/16",
+		fmt.Sprintf("route_rules.%d.network_entity_id", drg): "ocid1.drg.oc1.phx.aaaa",
+	}, is.Attributes)
+}
+
+func (s *RuleSetMigrationTestSuite) TestMigrateEmptyState() {
+	is, err := migrateRouteTableState(0, &terraform.InstanceState{}, nil)
+	s.NoError(err)
+	s.Empty(is.Attributes)
+
+	_, err = migrateSecurityListState(2, &terraform.InstanceState{ID: "ocid1.securitylist.oc1.phx.aaaa"}, nil)
+	s.Error(err)
+}
+
+func TestRuleSetMigrationTestSuite(t *testing.T) {
+	suite.Run(t, new(RuleSetMigrationTestSuite))
+}
+
+type SecurityRuleChecksTestSuite struct {
+	suite.Suite
+}
+
+func (s *SecurityRuleChecksTestSuite) tcpIngress(source string, min, max uint64) baremetal.IngressSecurityRule {
+	return baremetal.IngressSecurityRule{
+		Protocol:   "6",
+		Source:     source,
+		TCPOptions: &baremetal.TCPOptions{DestinationPortRange: baremetal.PortRange{Min: min, Max: max}},
+	}
+}
+
+func (s *SecurityRuleChecksTestSuite) TestValidateProtocol() {
+	for _, protocol := range []string{"all", "tcp", "UDP", "icmp", "icmpv6", "0", "6", "255"} {
+		_, es := validateProtocol(protocol, "protocol")
+		s.Empty(es, protocol)
+	}
+	for _, protocol := range []string{"", "-1", "256", "sctp", "tcp6"} {
+		_, es := validateProtocol(protocol, "protocol")
+		s.Len(es, 1, protocol)
+	}
+}
+
+func (s *SecurityRuleChecksTestSuite) TestNormalizeProtocol() {
+	s.Equal("6", normalizeProtocol("tcp"))
+	s.Equal("17", normalizeProtocol("UDP"))
+	s.Equal("1", normalizeProtocol("icmp"))
+	s.Equal("58", normalizeProtocol("icmpv6"))
+	s.Equal("all", normalizeProtocol("ALL"))
+	s.Equal("6", normalizeProtocol("6"))
+}
+
+func (s *SecurityRuleChecksTestSuite) TestCheckSecurityRules() {
+	s.NoError(checkSecurityRules(
+		[]baremetal.EgressSecurityRule{{Destination: "0.0.0.0/0", Protocol: "all"}},
+		[]baremetal.IngressSecurityRule{s.tcpIngress("10.0.0.0/16", 22, 22)},
+	))
+
+	err := checkSecurityRules(
+		[]baremetal.EgressSecurityRule{{
+			Destination: "0.0.0.0/0",
+			Protocol:    "17",
+			TCPOptions:  &baremetal.TCPOptions{DestinationPortRange: baremetal.PortRange{Min: 53, Max: 53}},
+		}},
+		[]baremetal.IngressSecurityRule{s.tcpIngress("10.0.0.0/16", 443, 80)},
+	)
+	s.Error(err)
+	s.Contains(err.Error(), "has tcp_options but its protocol isn't tcp")
+	s.Contains(err.Error(), "from 443 down to 80")
+}
+
+func (s *SecurityRuleChecksTestSuite) TestLintShadowedRules() {
+	warnings := lintSecurityRules(nil, []baremetal.IngressSecurityRule{
+		s.tcpIngress("10.0.0.0/16", 80, 443),
+		s.tcpIngress("10.0.1.0/24", 443, 443),
+		s.tcpIngress("10.1.0.0/24", 443, 443),
+	})
+	s.Len(warnings, 1)
+	s.Contains(warnings[0], "10.0.1.0/24")
+	s.Contains(warnings[0], "is shadowed by")
+}
+
+func (s *SecurityRuleChecksTestSuite) TestLintOpenSensitivePorts() {
+	warnings := lintSecurityRules(
+		[]baremetal.EgressSecurityRule{{Destination: "0.0.0.0/0", Protocol: "all"}},
+		[]baremetal.IngressSecurityRule{
+			s.tcpIngress("0.0.0.0/0", 22, 22),
+			s.tcpIngress("0.0.0.0/0", 80, 80),
+			s.tcpIngress("10.0.0.0/16", 3389, 3389),
+		},
+	)
+	s.Equal([]string{
+		fmt.Sprintf("ingress rule %s opens port 22 (SSH) to 0.0.0.0/0", ingressRuleKey(s.tcpIngress("0.0.0.0/0", 22, 22))),
+	}, warnings)
+
+	warnings = lintSecurityRules(nil, []baremetal.IngressSecurityRule{{Protocol: "all", Source: "0.0.0.0/0"}})
+	s.Len(warnings, 2)
+	s.Contains(warnings[1], "3389 (RDP)")
+}
+
+func TestSecurityRuleChecksTestSuite(t *testing.T) {
+	suite.Run(t, new(SecurityRuleChecksTestSuite))
+}
+
+type SecurityListUpdateTestSuite struct {
+	suite.Suite
+	Client *mocks.BareMetalClient
+	Stale  *baremetal.Error
+}
+
+func (s *SecurityListUpdateTestSuite) SetupTest() {
+	s.Client = &mocks.BareMetalClient{}
+	s.Stale = &baremetal.Error{Status: "412", Code: "NoEtagMatch"}
+
+	list := &baremetal.SecurityList{}
+	list.ETag = "fresh"
+	s.Client.On("GetSecurityList", "list").Return(list, nil)
+}
+
+// update lets updateSecurityListRules write the list with c and returns the
+// If-Match of every attempt.
+func (s *SecurityListUpdateTestSuite) update(c client.BareMetalClient, ifMatch string) (sent []string, e error) {
+	s.Client.On("UpdateSecurityList", "list", mock.Anything).Return(nil, s.Stale).Run(func(args mock.Arguments) {
+		sent = append(sent, args.Get(1).(*baremetal.UpdateSecurityListOptions).IfMatch)
+	})
+	_, e = updateSecurityListRules(c, "list", ifMatch, func(*baremetal.SecurityList) error { return nil })
+	return
+}
+
+func (s *SecurityListUpdateTestSuite) TestWithoutETags() {
+	sent, e := s.update(s.Client, "")
+	s.Equal(s.Stale, e)
+	s.Equal([]string{""}, sent)
+}
+
+func (s *SecurityListUpdateTestSuite) TestRetryWithFreshETags() {
+	c := &crud.Client{BareMetalClient: s.Client, Settings: crud.Settings{UseETags: true}}
+	sent, e := s.update(c, "")
+	s.Equal(s.Stale, e)
+	s.Len(sent, securityListUpdateAttempts)
+	s.Equal("fresh", sent[0])
+}
+
+func (s *SecurityListUpdateTestSuite) TestGivenETagIsNotRetried() {
+	c := &crud.Client{BareMetalClient: s.Client, Settings: crud.Settings{UseETags: true}}
+	sent, e := s.update(c, "refreshed")
+	s.Equal(s.Stale, e)
+	s.Equal([]string{"refreshed"}, sent)
+}
+
+func TestSecurityListUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(SecurityListUpdateTestSuite))
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// databaseDetailsResource is the database block of DB homes, both inline in a
+// DB system and on their own.
+var databaseDetailsResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"admin_password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"db_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"character_set": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ncharacter_set": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	},
+}
+
+// databaseDetails reads a database block.
+func databaseDetails(raw []interface{}) baremetal.CreateDatabaseDetails {
+	db := raw[0].(map[string]interface{})
+	return baremetal.NewCreateDatabaseDetails(
+		db["admin_password"].(string),
+		db["db_name"].(string),
+		db["character_set"].(string),
+		db["ncharacter_set"].(string),
+	)
+}
@@ -2,9 +2,15 @@
 
 package main
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
 
 var baseIdentitySchemaWithID = map[string]*schema.Schema{
+	"etag": crud.ETagSchema,
 	"id": {
 		Type:     schema.TypeString,
 		Computed: true,
@@ -99,6 +105,7 @@ var identitySchema = map[string]*schema.Schema{
 }
 
 var identitySchemaWithID = map[string]*schema.Schema{
+	"etag": crud.ETagSchema,
 	"id": {
 		Type:     schema.TypeString,
 		Computed: true,
@@ -130,3 +137,17 @@ var identitySchemaWithID = map[string]*schema.Schema{
 		Computed: true,
 	},
 }
+
+// compartmentMap flattens a compartment into the attributes of the
+// compartments data source.
+func compartmentMap(v baremetal.Compartment) map[string]interface{} {
+	return map[string]interface{}{
+		"compartment_id": v.CompartmentID,
+		"description":    v.Description,
+		"id":             v.ID,
+		"inactive_state": v.InactiveStatus,
+		"name":           v.Name,
+		"state":          v.State,
+		"time_created":   v.TimeCreated.String(),
+	}
+}
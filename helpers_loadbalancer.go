@@ -2,7 +2,15 @@
 
 package main
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
 
 var HealthCheckerSchema = &schema.Schema{
 	Type:     schema.TypeList,
@@ -58,3 +66,386 @@ var SSLConfigSchema = &schema.Schema{
 		},
 	},
 }
+
+const (
+	loadBalancerOCIDPrefix            = "ocid1.loadbalancer."
+	loadBalancerWorkRequestOCIDPrefix = "ocid1.loadbalancerworkrequest."
+)
+
+func validateLoadBalancerImportID(id string) error {
+	if strings.HasPrefix(id, loadBalancerWorkRequestOCIDPrefix) {
+		return fmt.Errorf("%s is a work request, import the load balancer it created instead", id)
+	}
+	if !strings.HasPrefix(id, loadBalancerOCIDPrefix) {
+		return fmt.Errorf("%s is not a load balancer OCID", id)
+	}
+	return nil
+}
+
+// importLoadBalancer imports a load balancer by its OCID.
+func importLoadBalancer(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := validateLoadBalancerImportID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// splitLoadBalancerImportID splits a composite import ID of the form
+// <load_balancer_id>/<part>/... into the load balancer OCID and n other parts.
+func splitLoadBalancerImportID(id string, format string, n int) (loadBalancerID string, parts []string, err error) {
+	parts = strings.SplitN(id, "/", n+1)
+	if len(parts) != n+1 {
+		err = fmt.Errorf("Import ID %q must be of the form %s", id, format)
+		return
+	}
+	for _, part := range parts {
+		if part == "" {
+			err = fmt.Errorf("Import ID %q must be of the form %s", id, format)
+			return
+		}
+	}
+	if err = validateLoadBalancerImportID(parts[0]); err != nil {
+		return
+	}
+	return parts[0], parts[1:], nil
+}
+
+// importLoadBalancerChild returns an importer for backend sets, listeners
+// and certificates, which are identified by their name within a load
+// balancer: <load_balancer_id>/<name>.
+func importLoadBalancerChild(nameField string) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		loadBalancerID, parts, err := splitLoadBalancerImportID(d.Id(), "<load_balancer_id>/<"+nameField+">", 1)
+		if err != nil {
+			return nil, err
+		}
+		d.Set("load_balancer_id", loadBalancerID)
+		d.Set(nameField, parts[0])
+		d.SetId(parts[0])
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importLoadBalancerBackend imports a backend as
+// <load_balancer_id>/<backendset_name>/<ip_address>:<port>.
+func importLoadBalancerBackend(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	format := "<load_balancer_id>/<backendset_name>/<ip_address>:<port>"
+	loadBalancerID, parts, err := splitLoadBalancerImportID(d.Id(), format, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	host, portStr, err := net.SplitHostPort(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("Import ID %q must be of the form %s", d.Id(), format)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("Import ID %q has an invalid port %q", d.Id(), portStr)
+	}
+
+	d.Set("load_balancer_id", loadBalancerID)
+	d.Set("backendset_name", parts[0])
+	d.Set("ip_address", host)
+	d.Set("port", port)
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// The load balancer resource can define its backend sets, listeners and
+// certificates inline. Each is keyed by its name, which the API uses to
+// identify it within the load balancer. Only the names declared inline are
+// tracked, so the blocks aren't computed.
+var loadBalancerBackendSetsSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"policy": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"health_checker":    HealthCheckerSchema,
+			"ssl_configuration": SSLConfigSchema,
+			"backend": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"backup": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"drain": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"offline": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+var loadBalancerListenersSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"default_backend_set_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"port": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ssl_configuration": SSLConfigSchema,
+		},
+	},
+}
+
+var loadBalancerCertificatesSchema = &schema.Schema{
+	Type:     schema.TypeSet,
+	Optional: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"certificate_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"public_certificate": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"passphrase": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"ca_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	},
+}
+
+func loadBalancerHealthChecker(vs []interface{}) *baremetal.HealthChecker {
+	if len(vs) != 1 || vs[0] == nil {
+		return nil
+	}
+	v := vs[0].(map[string]interface{})
+	return &baremetal.HealthChecker{
+		IntervalInMS:      v["interval_ms"].(int),
+		Port:              v["port"].(int),
+		Protocol:          v["protocol"].(string),
+		ResponseBodyRegex: v["response_body_regex"].(string),
+		URLPath:           v["url_path"].(string),
+	}
+}
+
+func loadBalancerSSLConfig(vs []interface{}) *baremetal.SSLConfiguration {
+	if len(vs) != 1 || vs[0] == nil {
+		return nil
+	}
+	v := vs[0].(map[string]interface{})
+	return &baremetal.SSLConfiguration{
+		CertificateName:       v["certificate_name"].(string),
+		VerifyDepth:           v["verify_depth"].(int),
+		VerifyPeerCertificate: v["verify_peer_certificate"].(bool),
+	}
+}
+
+func healthCheckerToList(h *baremetal.HealthChecker) []interface{} {
+	if h == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"interval_ms":         h.IntervalInMS,
+		"port":                h.Port,
+		"protocol":            h.Protocol,
+		"response_body_regex": h.ResponseBodyRegex,
+		"url_path":            h.URLPath,
+	}}
+}
+
+func sslConfigToList(c *baremetal.SSLConfiguration) []interface{} {
+	if c == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"certificate_name":        c.CertificateName,
+		"verify_depth":            c.VerifyDepth,
+		"verify_peer_certificate": c.VerifyPeerCertificate,
+	}}
+}
+
+// loadBalancerBackendSets converts backend_set blocks to backend sets keyed
+// by name.
+func loadBalancerBackendSets(set *schema.Set) map[string]baremetal.BackendSet {
+	backendSets := map[string]baremetal.BackendSet{}
+	for _, raw := range set.List() {
+		v := raw.(map[string]interface{})
+		backendSet := baremetal.BackendSet{
+			Name:          v["name"].(string),
+			Policy:        v["policy"].(string),
+			HealthChecker: loadBalancerHealthChecker(v["health_checker"].([]interface{})),
+			SSLConfig:     loadBalancerSSLConfig(v["ssl_configuration"].([]interface{})),
+			Backends:      []baremetal.Backend{},
+		}
+		if backends, ok := v["backend"].(*schema.Set); ok {
+			for _, rawBackend := range backends.List() {
+				b := rawBackend.(map[string]interface{})
+				backendSet.Backends = append(backendSet.Backends, baremetal.Backend{
+					IPAddress: b["ip_address"].(string),
+					Port:      b["port"].(int),
+					Backup:    b["backup"].(bool),
+					Drain:     b["drain"].(bool),
+					Offline:   b["offline"].(bool),
+					Weight:    b["weight"].(int),
+				})
+			}
+		}
+		backendSets[backendSet.Name] = backendSet
+	}
+	return backendSets
+}
+
+// loadBalancerListeners converts listener blocks to listeners keyed by name.
+func loadBalancerListeners(set *schema.Set) map[string]baremetal.Listener {
+	listeners := map[string]baremetal.Listener{}
+	for _, raw := range set.List() {
+		v := raw.(map[string]interface{})
+		listener := baremetal.Listener{
+			Name:                  v["name"].(string),
+			DefaultBackendSetName: v["default_backend_set_name"].(string),
+			Port:                  v["port"].(int),
+			Protocol:              v["protocol"].(string),
+			SSLConfig:             loadBalancerSSLConfig(v["ssl_configuration"].([]interface{})),
+		}
+		listeners[listener.Name] = listener
+	}
+	return listeners
+}
+
+// loadBalancerCertificates converts certificate blocks to certificates keyed
+// by name.
+func loadBalancerCertificates(set *schema.Set) map[string]baremetal.Certificate {
+	certificates := map[string]baremetal.Certificate{}
+	for _, raw := range set.List() {
+		v := raw.(map[string]interface{})
+		certificate := baremetal.Certificate{
+			CertificateName:   v["certificate_name"].(string),
+			PublicCertificate: v["public_certificate"].(string),
+			PrivateKey:        v["private_key"].(string),
+			Passphrase:        v["passphrase"].(string),
+			CACertificate:     v["ca_certificate"].(string),
+		}
+		certificates[certificate.CertificateName] = certificate
+	}
+	return certificates
+}
+
+// backendSetsToSet only returns the backend sets in declared.
+func backendSetsToSet(backendSets map[string]baremetal.BackendSet, declared map[string]baremetal.BackendSet) []interface{} {
+	result := []interface{}{}
+	for name, backendSet := range backendSets {
+		if _, ok := declared[name]; !ok {
+			continue
+		}
+		backends := []interface{}{}
+		for _, b := range backendSet.Backends {
+			backends = append(backends, map[string]interface{}{
+				"ip_address": b.IPAddress,
+				"port":       b.Port,
+				"backup":     b.Backup,
+				"drain":      b.Drain,
+				"offline":    b.Offline,
+				"weight":     b.Weight,
+			})
+		}
+		result = append(result, map[string]interface{}{
+			"name":              name,
+			"policy":            backendSet.Policy,
+			"health_checker":    healthCheckerToList(backendSet.HealthChecker),
+			"ssl_configuration": sslConfigToList(backendSet.SSLConfig),
+			"backend":           backends,
+		})
+	}
+	return result
+}
+
+// listenersToSet only returns the listeners in declared.
+func listenersToSet(listeners map[string]baremetal.Listener, declared map[string]baremetal.Listener) []interface{} {
+	result := []interface{}{}
+	for name, listener := range listeners {
+		if _, ok := declared[name]; !ok {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"name":                     name,
+			"default_backend_set_name": listener.DefaultBackendSetName,
+			"port":                     listener.Port,
+			"protocol":                 listener.Protocol,
+			"ssl_configuration":        sslConfigToList(listener.SSLConfig),
+		})
+	}
+	return result
+}
+
+// certificatesToSet only returns the certificates in known, keeping their
+// private keys and passphrases, which the API never returns.
+func certificatesToSet(certificates map[string]baremetal.Certificate, known map[string]baremetal.Certificate) []interface{} {
+	result := []interface{}{}
+	for name, certificate := range certificates {
+		if _, ok := known[name]; !ok {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"certificate_name":   name,
+			"public_certificate": certificate.PublicCertificate,
+			"private_key":        known[name].PrivateKey,
+			"passphrase":         known[name].Passphrase,
+			"ca_certificate":     certificate.CACertificate,
+		})
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+)
+
+const testLoadBalancerOCID = "ocid1.loadbalancer.oc1.phx.aaaa"
+
+type LoadBalancerImportTestSuite struct {
+	suite.Suite
+}
+
+func (s *LoadBalancerImportTestSuite) importID(r *schema.Resource, id string) (*schema.ResourceData, error) {
+	d := r.Data(nil)
+	d.SetId(id)
+	imported, err := r.Importer.State(d, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.Require().Len(imported, 1)
+	return imported[0], nil
+}
+
+func (s *LoadBalancerImportTestSuite) TestLoadBalancer() {
+	d, err := s.importID(LoadBalancerResource(), testLoadBalancerOCID)
+	s.Require().NoError(err)
+	s.Equal(testLoadBalancerOCID, d.Id())
+
+	_, err = s.importID(LoadBalancerResource(), "ocid1.loadbalancerworkrequest.oc1.phx.aaaa")
+	s.Require().Error(err)
+	s.Contains(err.Error(), "is a work request")
+
+	_, err = s.importID(LoadBalancerResource(), "ocid1.instance.oc1.phx.aaaa")
+	s.Error(err)
+}
+
+func (s *LoadBalancerImportTestSuite) TestNamedChildren() {
+	d, err := s.importID(LoadBalancerBackendSetResource(), testLoadBalancerOCID+"/backendset")
+	s.Require().NoError(err)
+	s.Equal("backendset", d.Id())
+	s.Equal(testLoadBalancerOCID, d.Get("load_balancer_id"))
+	s.Equal("backendset", d.Get("name"))
+
+	d, err = s.importID(LoadBalancerListenerResource(), testLoadBalancerOCID+"/http")
+	s.Require().NoError(err)
+	s.Equal("http", d.Id())
+	s.Equal("http", d.Get("name"))
+
+	d, err = s.importID(LoadBalancerCertificateResource(), testLoadBalancerOCID+"/cert")
+	s.Require().NoError(err)
+	s.Equal("cert", d.Id())
+	s.Equal(testLoadBalancerOCID, d.Get("load_balancer_id"))
+	s.Equal("cert", d.Get("certificate_name"))
+}
+
+func (s *LoadBalancerImportTestSuite) TestBackend() {
+	d, err := s.importID(LoadBalancerBackendResource(), testLoadBalancerOCID+"/backendset/10.0.0.3:8080")
+	s.Require().NoError(err)
+	s.Equal("10.0.0.3:8080", d.Id())
+	s.Equal(testLoadBalancerOCID, d.Get("load_balancer_id"))
+	s.Equal("backendset", d.Get("backendset_name"))
+	s.Equal("10.0.0.3", d.Get("ip_address"))
+	s.Equal(8080, d.Get("port"))
+}
+
+func (s *LoadBalancerImportTestSuite) TestInvalidIDs() {
+	for _, id := range []string{
+		"backendset",
+		testLoadBalancerOCID + "/",
+		"ocid1.loadbalancerworkrequest.oc1.phx.aaaa/backendset",
+	} {
+		_, err := s.importID(LoadBalancerBackendSetResource(), id)
+		s.Error(err, id)
+	}
+
+	for _, id := range []string{
+		testLoadBalancerOCID + "/backendset",
+		testLoadBalancerOCID + "/backendset/10.0.0.3",
+		testLoadBalancerOCID + "/backendset/10.0.0.3:http",
+	} {
+		_, err := s.importID(LoadBalancerBackendResource(), id)
+		s.Error(err, id)
+	}
+}
+
+func TestLoadBalancerImportTestSuite(t *testing.T) {
+	suite.Run(t, new(LoadBalancerImportTestSuite))
+}
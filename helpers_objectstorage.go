@@ -6,6 +6,8 @@ import (
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
 func resourceObjectStorageMapToMetadata(rm map[string]interface{}) map[string]string {
@@ -17,6 +19,7 @@ func resourceObjectStorageMapToMetadata(rm map[string]interface{}) map[string]st
 }
 
 var bucketSchema = map[string]*schema.Schema{
+	"etag": crud.ETagSchema,
 	"compartment_id": {
 		Type:     schema.TypeString,
 		Required: true,
@@ -48,6 +51,7 @@ var bucketSchema = map[string]*schema.Schema{
 }
 
 var objectSchema = map[string]*schema.Schema{
+	"etag": crud.ETagSchema,
 	"namespace": {
 		Type:     schema.TypeString,
 		Required: true,
@@ -0,0 +1,275 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+// Package httpreplay records the provider's API traffic to a fixture file
+// and plays it back, so that acceptance tests can be rerun offline without
+// waiting on real resources.
+//
+// Requests are matched on method, path, query and body. Headers, including
+// the request signature and date, are ignored.
+//
+// Recorded interactions are kept in memory and written to the fixture by
+// Save, or by SaveAll for the transports returned by Open, once recording is
+// done.
+package httpreplay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Modes accepted by NewTransport.
+const (
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+// Request is the recorded part of an http.Request.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an http.Response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a single request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Transport is an http.RoundTripper that either records the interactions
+// it passes to an underlying transport or replays previously recorded ones.
+type Transport struct {
+	mode    string
+	path    string
+	inner   http.RoundTripper
+	mu      sync.Mutex
+	records []Interaction
+	// replay queues are keyed by request, and consumed in recorded order.
+	// The last response of a queue is repeated once it is exhausted, so
+	// that extra polling during replay still sees the final state.
+	queues map[string][]Interaction
+	last   map[string]Interaction
+}
+
+// NewTransport returns a Transport for mode ("record" or "replay") backed
+// by the fixture file at path. inner is only used when recording.
+func NewTransport(mode, path string, inner http.RoundTripper) (t *Transport, e error) {
+	t = &Transport{
+		mode:   mode,
+		path:   path,
+		inner:  inner,
+		queues: map[string][]Interaction{},
+		last:   map[string]Interaction{},
+	}
+
+	switch mode {
+	case ModeRecord:
+		if inner == nil {
+			e = errors.New("httpreplay: a transport is required to record")
+		}
+	case ModeReplay:
+		e = t.load()
+	default:
+		e = fmt.Errorf("httpreplay: unknown mode %q, expected %q or %q", mode, ModeRecord, ModeReplay)
+	}
+	return
+}
+
+func (t *Transport) load() (e error) {
+	var buff []byte
+	if buff, e = ioutil.ReadFile(t.path); e != nil {
+		return
+	}
+	if e = json.Unmarshal(buff, &t.records); e != nil {
+		return fmt.Errorf("httpreplay: cannot parse fixture %s: %s", t.path, e)
+	}
+	for _, i := range t.records {
+		k := key(i.Request)
+		t.queues[k] = append(t.queues[k], i)
+	}
+	return
+}
+
+var (
+	openMu sync.Mutex
+	opened = map[string]*Transport{}
+)
+
+// Open returns the Transport for the fixture at path, creating it with
+// NewTransport the first time. Every provider configured during a test run
+// then records into, or replays from, the same Transport, so a fixture
+// covers the whole run.
+func Open(mode, path string, inner http.RoundTripper) (t *Transport, e error) {
+	openMu.Lock()
+	defer openMu.Unlock()
+
+	if t = opened[path]; t != nil {
+		if t.mode != mode {
+			e = fmt.Errorf("httpreplay: %s is already open in %s mode", path, t.mode)
+		}
+		return
+	}
+	if t, e = NewTransport(mode, path, inner); e != nil {
+		return
+	}
+	opened[path] = t
+	return
+}
+
+// SaveAll saves the fixtures of the transports returned by Open that are
+// recording. Call it once the test run is over.
+func SaveAll() (e error) {
+	openMu.Lock()
+	defer openMu.Unlock()
+
+	for _, t := range opened {
+		if t.mode != ModeRecord {
+			continue
+		}
+		if e = t.Save(); e != nil {
+			return
+		}
+	}
+	return
+}
+
+// Save writes every interaction recorded so far to the fixture.
+func (t *Transport) Save() (e error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var buff []byte
+	if buff, e = json.MarshalIndent(t.records, "", "  "); e != nil {
+		return
+	}
+	if e = os.MkdirAll(filepath.Dir(t.path), os.FileMode(0755)); e != nil {
+		return
+	}
+	return ioutil.WriteFile(t.path, buff, os.FileMode(0644))
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (res *http.Response, e error) {
+	var recReq Request
+	if recReq, e = newRequest(req); e != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.mode == ModeReplay {
+		return t.replay(req, recReq)
+	}
+	return t.record(req, recReq)
+}
+
+func (t *Transport) record(req *http.Request, recReq Request) (res *http.Response, e error) {
+	if res, e = t.inner.RoundTrip(req); e != nil {
+		return
+	}
+
+	var body []byte
+	body, e = ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if e != nil {
+		return
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	t.records = append(t.records, Interaction{
+		Request: recReq,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header,
+			Body:       string(body),
+		},
+	})
+	return
+}
+
+func (t *Transport) replay(req *http.Request, recReq Request) (res *http.Response, e error) {
+	k := key(recReq)
+
+	var i Interaction
+	if q := t.queues[k]; len(q) > 0 {
+		i, t.queues[k] = q[0], q[1:]
+		t.last[k] = i
+	} else if prev, ok := t.last[k]; ok {
+		i = prev
+	} else {
+		e = fmt.Errorf("httpreplay: no recorded interaction for %s %s?%s in %s", recReq.Method, recReq.Path, recReq.Query, t.path)
+		return
+	}
+
+	body := []byte(i.Response.Body)
+	res = &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+	for name, values := range i.Response.Header {
+		res.Header[name] = append([]string{}, values...)
+	}
+	return
+}
+
+func newRequest(req *http.Request) (r Request, e error) {
+	r = Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		// Encode sorts the parameters by key.
+		Query: req.URL.Query().Encode(),
+	}
+
+	if req.Body == nil {
+		return
+	}
+
+	var body []byte
+	if body, e = ioutil.ReadAll(req.Body); e != nil {
+		return
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.Body = normalizeBody(body)
+	return
+}
+
+// normalizeBody compacts JSON bodies so that formatting differences do not
+// prevent a match. Other bodies are kept as they are.
+func normalizeBody(body []byte) string {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if dec.Decode(&v) != nil {
+		return string(body)
+	}
+	compact, _ := json.Marshal(v)
+	return string(compact)
+}
+
+func key(r Request) string {
+	return r.Method + " " + r.Path + "?" + r.Query + "\n" + r.Body
+}
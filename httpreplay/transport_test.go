@@ -0,0 +1,151 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package httpreplay
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransportTestSuite struct {
+	suite.Suite
+	Dir     string
+	Fixture string
+	Server  *httptest.Server
+	Calls   int
+}
+
+func (s *TransportTestSuite) SetupTest() {
+	var err error
+	s.Dir, err = ioutil.TempDir("", "httpreplay")
+	s.Require().NoError(err)
+	s.Fixture = filepath.Join(s.Dir, "fixture.json")
+
+	s.Calls = 0
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("opc-request-id", fmt.Sprintf("request%d", s.Calls))
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"call":%d,"body":%q}`, s.Calls, body)
+	}))
+}
+
+func (s *TransportTestSuite) TearDownTest() {
+	s.Server.Close()
+	os.RemoveAll(s.Dir)
+}
+
+func (s *TransportTestSuite) do(t http.RoundTripper, method, path, body string, header http.Header) (res *http.Response, resBody string) {
+	req, err := http.NewRequest(method, s.Server.URL+path, strings.NewReader(body))
+	s.Require().NoError(err)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	res, err = (&http.Client{Transport: t}).Do(req)
+	s.Require().NoError(err)
+	buff, err := ioutil.ReadAll(res.Body)
+	s.Require().NoError(err)
+	res.Body.Close()
+	return res, string(buff)
+}
+
+func (s *TransportTestSuite) record() {
+	t, err := NewTransport(ModeRecord, s.Fixture, http.DefaultTransport)
+	s.Require().NoError(err)
+
+	signed := http.Header{"Authorization": {`Signature keyId="a/b/c",signature="first"`}}
+	_, body := s.do(t, http.MethodGet, "/instances/ocid1?b=2&a=1", "", signed)
+	s.Equal(`{"call":1,"body":""}`, body)
+	s.do(t, http.MethodGet, "/instances/ocid1?b=2&a=1", "", signed)
+	s.do(t, http.MethodPost, "/instances", `{"shape": "VM", "displayName": "t"}`, signed)
+	s.Equal(3, s.Calls)
+
+	_, err = os.Stat(s.Fixture)
+	s.True(os.IsNotExist(err), "The fixture should only be written once recording is done")
+	s.Require().NoError(t.Save())
+}
+
+func (s *TransportTestSuite) TestReplayMatchesRecordedRequests() {
+	s.record()
+
+	t, err := NewTransport(ModeReplay, s.Fixture, nil)
+	s.Require().NoError(err)
+
+	// Different signature, parameter order and JSON formatting.
+	signed := http.Header{"Authorization": {`Signature keyId="a/b/c",signature="second"`}}
+	res, body := s.do(t, http.MethodPost, "/instances", `{"displayName":"t","shape":"VM"}`, signed)
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("request3", res.Header.Get("opc-request-id"))
+	s.Contains(body, `"call":3`)
+
+	// Repeated requests are replayed in recorded order, then the last
+	// response is repeated.
+	_, body = s.do(t, http.MethodGet, "/instances/ocid1?a=1&b=2", "", signed)
+	s.Contains(body, `"call":1`)
+	_, body = s.do(t, http.MethodGet, "/instances/ocid1?a=1&b=2", "", signed)
+	s.Contains(body, `"call":2`)
+	_, body = s.do(t, http.MethodGet, "/instances/ocid1?a=1&b=2", "", signed)
+	s.Contains(body, `"call":2`)
+
+	// Nothing reached the server.
+	s.Equal(3, s.Calls)
+}
+
+func (s *TransportTestSuite) TestReplayUnrecordedRequest() {
+	s.record()
+
+	t, err := NewTransport(ModeReplay, s.Fixture, nil)
+	s.Require().NoError(err)
+
+	req, _ := http.NewRequest(http.MethodPost, s.Server.URL+"/instances", strings.NewReader(`{"shape":"BM"}`))
+	_, err = t.RoundTrip(req)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "no recorded interaction for POST /instances")
+}
+
+func (s *TransportTestSuite) TestReplayMissingFixture() {
+	_, err := NewTransport(ModeReplay, filepath.Join(s.Dir, "missing.json"), nil)
+	s.Error(err)
+}
+
+func (s *TransportTestSuite) TestUnknownMode() {
+	_, err := NewTransport("rewind", s.Fixture, http.DefaultTransport)
+	s.Require().Error(err)
+	s.Contains(err.Error(), `unknown mode "rewind"`)
+}
+
+func (s *TransportTestSuite) TestOpenSharesTransports() {
+	first, err := Open(ModeRecord, s.Fixture, http.DefaultTransport)
+	s.Require().NoError(err)
+	second, err := Open(ModeRecord, s.Fixture, http.DefaultTransport)
+	s.Require().NoError(err)
+	s.True(first == second, "Opening a fixture again should return the same transport")
+
+	_, err = Open(ModeReplay, s.Fixture, nil)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "already open in record mode")
+
+	s.do(first, http.MethodGet, "/instances/ocid1", "", nil)
+	s.do(second, http.MethodGet, "/instances/ocid2", "", nil)
+	s.Require().NoError(SaveAll())
+
+	replay, err := NewTransport(ModeReplay, s.Fixture, nil)
+	s.Require().NoError(err)
+	_, body := s.do(replay, http.MethodGet, "/instances/ocid1", "", nil)
+	s.Contains(body, `"call":1`)
+	_, body = s.do(replay, http.MethodGet, "/instances/ocid2", "", nil)
+	s.Contains(body, `"call":2`)
+}
+
+func TestTransportTestSuite(t *testing.T) {
+	suite.Run(t, new(TransportTestSuite))
+}
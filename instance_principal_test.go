@@ -0,0 +1,80 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/fakeapi"
+)
+
+const instancePrincipalTenancy = "ocid1.tenancy.oc1..instancetenancy"
+
+type InstancePrincipalTestSuite struct {
+	suite.Suite
+	Server   *fakeapi.Server
+	Metadata *fakeapi.MetadataServer
+	env      map[string]string
+}
+
+func (s *InstancePrincipalTestSuite) SetupTest() {
+	s.Server = fakeapi.NewServer()
+	s.Metadata = fakeapi.NewMetadataServer(instancePrincipalTenancy)
+
+	s.env = map[string]string{}
+	for k, v := range map[string]string{
+		"OBMCS_url_template":          s.Server.URLTemplate(),
+		"OBMCS_allow_insecure_tls":    "true",
+		"OBMCS_instance_metadata_url": s.Metadata.URL(),
+	} {
+		s.env[k] = os.Getenv(k)
+		os.Setenv(k, v)
+	}
+}
+
+func (s *InstancePrincipalTestSuite) TearDownTest() {
+	for k, v := range s.env {
+		os.Setenv(k, v)
+	}
+	s.Server.Close()
+	s.Metadata.Close()
+}
+
+func (s *InstancePrincipalTestSuite) TestProviderConfig() {
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	d.Set("auth", authInstancePrincipal)
+
+	client, err := providerConfig(d)
+	s.Require().NoError(err)
+
+	compartments, err := client.(*crud.Client).ListCompartments(nil)
+	s.Require().NoError(err)
+	s.Require().Len(compartments.Compartments, 1)
+	s.Equal(instancePrincipalTenancy, compartments.Compartments[0].CompartmentID)
+	s.Equal(1, s.Server.TokensIssued())
+}
+
+func (s *InstancePrincipalTestSuite) TestProviderConfigWithoutMetadata() {
+	s.Metadata.Close()
+
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	d.Set("auth", authInstancePrincipal)
+
+	_, err := providerConfig(d)
+	s.Require().Error(err)
+	s.Contains(err.Error(), "Could not authenticate as an instance principal")
+}
+
+func (s *InstancePrincipalTestSuite) TestInvalidAuth() {
+	_, errs := schemaMap()["auth"].ValidateFunc("password", "auth")
+	s.Len(errs, 1)
+}
+
+func TestInstancePrincipalTestSuite(t *testing.T) {
+	suite.Run(t, new(InstancePrincipalTestSuite))
+}
@@ -2,7 +2,15 @@
 
 package options
 
-import "github.com/MustWin/baremetal-sdk-go"
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
 
 type resourceProvider interface {
 	GetOk(string) (interface{}, bool)
@@ -38,3 +46,238 @@ func SetNextPageOption(nextPage string, opts *baremetal.PageListOptions) (hasNex
 
 	return
 }
+
+// FilterSchema returns the filter block of data sources that return lists.
+// Items are kept when, for every filter, the attribute called name matches
+// one of the values. Attributes of nested blocks are named with dots, such as
+// "ingress_security_rules.source", and match when any of the nested blocks
+// does. Each data source gets its own copy, so changing one doesn't change
+// the others.
+func FilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"regex": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+type filter struct {
+	path    []string
+	values  []string
+	regexes []*regexp.Regexp
+}
+
+func getFilters(resource resourceProvider) (filters []filter, e error) {
+	val, ok := resource.GetOk("filter")
+	if !ok {
+		return
+	}
+
+	var confs []interface{}
+	switch v := val.(type) {
+	case *schema.Set:
+		confs = v.List()
+	case []interface{}:
+		confs = v
+	}
+
+	for _, conf := range confs {
+		conf := conf.(map[string]interface{})
+		f := filter{path: strings.Split(conf["name"].(string), ".")}
+		for _, value := range conf["values"].([]interface{}) {
+			f.values = append(f.values, value.(string))
+		}
+		if regex, _ := conf["regex"].(bool); regex {
+			for _, value := range f.values {
+				var re *regexp.Regexp
+				if re, e = regexp.Compile(value); e != nil {
+					return nil, fmt.Errorf("Invalid regular expression in filter %q: %v", conf["name"], e)
+				}
+				f.regexes = append(f.regexes, re)
+			}
+		}
+		filters = append(filters, f)
+	}
+	return
+}
+
+// CheckFilters returns the problems with the filters of a data source, so its
+// read can fail before anything is listed.
+func CheckFilters(resource resourceProvider) (e error) {
+	_, e = getFilters(resource)
+	return
+}
+
+// ApplyFilters returns the items that match all of the data source's
+// filters, in their original order.
+func ApplyFilters(resource resourceProvider, items []map[string]interface{}) []map[string]interface{} {
+	filters, e := getFilters(resource)
+	if e != nil || len(filters) == 0 {
+		return items
+	}
+
+	filtered := []map[string]interface{}{}
+	for _, item := range items {
+		if matchesAll(filters, item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// Matches reports whether item matches all of the data source's filters,
+// for data sources that filter items before working anything out from them.
+// Like ApplyFilters, it keeps everything when the filters are invalid.
+func Matches(resource resourceProvider, item map[string]interface{}) bool {
+	filters, e := getFilters(resource)
+	if e != nil {
+		return true
+	}
+	return matchesAll(filters, item)
+}
+
+func matchesAll(filters []filter, item map[string]interface{}) bool {
+	for _, f := range filters {
+		if !f.matches(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f filter) matches(item map[string]interface{}) bool {
+	for _, v := range lookup(item, f.path) {
+		s := fmt.Sprint(v)
+		if f.regexes != nil {
+			for _, re := range f.regexes {
+				if re.MatchString(s) {
+					return true
+				}
+			}
+			continue
+		}
+		for _, value := range f.values {
+			if s == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookup finds the values at path, going through any lists or sets on the
+// way.
+func lookup(v interface{}, path []string) (found []interface{}) {
+	if set, ok := v.(*schema.Set); ok {
+		v = set.List()
+	}
+	if v == nil {
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			found = append(found, lookup(rv.Index(i).Interface(), path)...)
+		}
+	case reflect.Map:
+		if len(path) == 0 || rv.Type().Key().Kind() != reflect.String {
+			return
+		}
+		if next := rv.MapIndex(reflect.ValueOf(path[0])); next.IsValid() {
+			found = lookup(next.Interface(), path[1:])
+		}
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			found = lookup(rv.Elem().Interface(), path)
+		}
+	default:
+		if len(path) == 0 {
+			found = append(found, v)
+		}
+	}
+	return
+}
+
+// SelectOne returns the one item, out of those listed by a singular data
+// source, that matches its filters. If several match and mostRecent is set,
+// the one created last is returned instead of failing. created holds the
+// creation time of each item and is only used then.
+func SelectOne(resource resourceProvider, kind string, items []map[string]interface{}, created []baremetal.Time, mostRecent bool) (map[string]interface{}, error) {
+	filters, _ := getFilters(resource)
+	var matched []int
+	for i, item := range items {
+		if matchesAll(filters, item) {
+			matched = append(matched, i)
+		}
+	}
+
+	switch {
+	case len(matched) == 0:
+		return nil, fmt.Errorf("No %s matches the arguments and filters", kind)
+	case len(matched) == 1:
+		return items[matched[0]], nil
+	case !mostRecent:
+		described := make([]map[string]interface{}, len(matched))
+		for j, i := range matched {
+			described[j] = items[i]
+		}
+		return nil, fmt.Errorf("%d %ss match the arguments and filters, add filters so that only one does: %s", len(matched), kind, describeItems(described))
+	}
+
+	newest := matched[0]
+	for _, i := range matched[1:] {
+		if created[i].After(created[newest].Time) {
+			newest = i
+		}
+	}
+	return items[newest], nil
+}
+
+// describeItems names items in errors by their OCIDs and names.
+func describeItems(items []map[string]interface{}) string {
+	described := []string{}
+	for _, v := range items {
+		name, _ := v["display_name"].(string)
+		if name == "" {
+			name, _ = v["name"].(string)
+		}
+		described = append(described, fmt.Sprintf("%v (%s)", v["id"], name))
+	}
+	return strings.Join(described, ", ")
+}
+
+type resourceSetter interface {
+	SetId(string)
+	Set(string, interface{}) error
+}
+
+// SetItem sets the attributes of a singular data source to those of the item
+// SelectOne returned. The item's OCID is the data source's ID, so it only
+// changes when another item is picked.
+func SetItem(resource resourceSetter, item map[string]interface{}) {
+	resource.SetId(fmt.Sprint(item["id"]))
+	for k, v := range item {
+		if k != "id" {
+			resource.Set(k, v)
+		}
+	}
+}
@@ -4,7 +4,9 @@ package options
 
 import (
 	"testing"
+	"time"
 
+	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -41,3 +43,143 @@ func (s *HelpersTestSuite) SetupTest() {
 func TestHelpers(t *testing.T) {
 	suite.Run(t, new(HelpersTestSuite))
 }
+
+func (s *HelpersTestSuite) setFilters(filters ...map[string]interface{}) {
+	confs := []interface{}{}
+	for _, f := range filters {
+		confs = append(confs, f)
+	}
+	s.resource.Set("filter", confs)
+}
+
+func (s *HelpersTestSuite) items() []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"display_name": "Public",
+			"state":        "AVAILABLE",
+			"ingress_security_rules": []map[string]interface{}{
+				{"source": "0.0.0.0/0", "tcp_options": []interface{}{map[string]interface{}{"min": 443, "max": 443}}},
+			},
+		},
+		{
+			"display_name": "Private",
+			"state":        "AVAILABLE",
+			"ingress_security_rules": []map[string]interface{}{
+				{"source": "10.0.0.0/16"},
+				{"source": "10.1.0.0/16"},
+			},
+		},
+		{
+			"display_name": "Old",
+			"state":        "TERMINATED",
+		},
+	}
+}
+
+func (s *HelpersTestSuite) names(items []map[string]interface{}) (names []string) {
+	for _, item := range items {
+		names = append(names, item["display_name"].(string))
+	}
+	return
+}
+
+func (s *HelpersTestSuite) TestApplyFiltersWithoutFilters() {
+	s.Equal(s.items(), ApplyFilters(s.resource, s.items()))
+}
+
+func (s *HelpersTestSuite) TestApplyFilters() {
+	s.setFilters(map[string]interface{}{"name": "state", "values": []interface{}{"AVAILABLE", "PROVISIONING"}})
+	s.Equal([]string{"Public", "Private"}, s.names(ApplyFilters(s.resource, s.items())))
+
+	s.setFilters(
+		map[string]interface{}{"name": "state", "values": []interface{}{"AVAILABLE"}},
+		map[string]interface{}{"name": "display_name", "values": []interface{}{"Private", "Old"}},
+	)
+	s.Equal([]string{"Private"}, s.names(ApplyFilters(s.resource, s.items())))
+
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"Missing"}})
+	s.Empty(ApplyFilters(s.resource, s.items()))
+}
+
+func (s *HelpersTestSuite) TestApplyFiltersToNestedAttributes() {
+	s.setFilters(map[string]interface{}{"name": "ingress_security_rules.source", "values": []interface{}{"10.1.0.0/16"}})
+	s.Equal([]string{"Private"}, s.names(ApplyFilters(s.resource, s.items())))
+
+	s.setFilters(map[string]interface{}{"name": "ingress_security_rules.tcp_options.min", "values": []interface{}{"443"}})
+	s.Equal([]string{"Public"}, s.names(ApplyFilters(s.resource, s.items())))
+}
+
+func (s *HelpersTestSuite) TestApplyRegexFilters() {
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"^P"}, "regex": true})
+	s.Equal([]string{"Public", "Private"}, s.names(ApplyFilters(s.resource, s.items())))
+
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"^P"}})
+	s.Empty(ApplyFilters(s.resource, s.items()))
+}
+
+func (s *HelpersTestSuite) TestMatches() {
+	items := s.items()
+	s.True(Matches(s.resource, items[0]))
+
+	s.setFilters(map[string]interface{}{"name": "state", "values": []interface{}{"AVAILABLE"}})
+	s.True(Matches(s.resource, items[1]))
+	s.False(Matches(s.resource, items[2]))
+}
+
+func (s *HelpersTestSuite) TestFilterSchemaIsNotShared() {
+	s.True(FilterSchema() != FilterSchema(), "Each data source should get its own filter schema")
+}
+
+func (s *HelpersTestSuite) TestCheckFilters() {
+	s.NoError(CheckFilters(s.resource))
+
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"("}})
+	s.NoError(CheckFilters(s.resource))
+
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"("}, "regex": true})
+	s.Error(CheckFilters(s.resource))
+}
+
+func (s *HelpersTestSuite) images() ([]map[string]interface{}, []baremetal.Time) {
+	items := []map[string]interface{}{
+		{"id": "ocid1.image.a", "display_name": "Oracle-Linux-7.3-2017.04.18-0"},
+		{"id": "ocid1.image.b", "display_name": "Oracle-Linux-7.3-2017.05.23-0"},
+		{"id": "ocid1.image.c", "display_name": "Windows-Server-2012-R2"},
+	}
+	created := []baremetal.Time{
+		{Time: time.Date(2017, 4, 18, 20, 8, 13, 123000000, time.UTC)},
+		{Time: time.Date(2017, 5, 23, 18, 1, 51, 0, time.UTC)},
+		{Time: time.Date(2017, 6, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	return items, created
+}
+
+func (s *HelpersTestSuite) TestSelectOne() {
+	images, created := s.images()
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"Windows-Server-2012-R2"}})
+	item, err := SelectOne(s.resource, "image", images, nil, false)
+	s.NoError(err)
+	s.Equal("ocid1.image.c", item["id"])
+
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"Ubuntu"}})
+	_, err = SelectOne(s.resource, "image", images, created, true)
+	s.EqualError(err, "No image matches the arguments and filters")
+}
+
+func (s *HelpersTestSuite) TestSelectOneAmbiguous() {
+	images, created := s.images()
+	s.setFilters(map[string]interface{}{"name": "display_name", "values": []interface{}{"^Oracle-Linux-7.3-"}, "regex": true})
+	_, err := SelectOne(s.resource, "image", images, created, false)
+	s.EqualError(err, "2 images match the arguments and filters, add filters so that only one does: "+
+		"ocid1.image.a (Oracle-Linux-7.3-2017.04.18-0), ocid1.image.b (Oracle-Linux-7.3-2017.05.23-0)")
+
+	item, err := SelectOne(s.resource, "image", images, created, true)
+	s.NoError(err)
+	s.Equal("ocid1.image.b", item["id"])
+
+	// The newest wins wherever it is in the list.
+	created[0] = baremetal.Time{Time: created[1].AddDate(1, 0, 0)}
+	item, err = SelectOne(s.resource, "image", images, created, true)
+	s.NoError(err)
+	s.Equal("ocid1.image.a", item["id"])
+}
@@ -9,10 +9,21 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/httpreplay"
+)
+
+const (
+	authAPIKey            = "api_key"
+	authInstancePrincipal = "instance_principal"
 )
 
 var descriptions map[string]string
@@ -28,36 +39,107 @@ func init() {
 			"A private_key or a private_key_path must be provided.",
 		"private_key_password": "(Optional) The password used to secure the private key.",
 		"region":               "(Optional) The region for API connections.",
+		"config_file_path": "(Optional) The path to an SDK/CLI style config file to read credentials from.\n" +
+			"Defaults to ~/.oraclebmc/config. Attributes set in the provider block or the environment override file values.",
+		"config_file_profile": "(Optional) The profile in the config file to use. Defaults to DEFAULT.",
+		"auth": "(Optional) How requests are authenticated, api_key (the default) or instance_principal.\n" +
+			"With instance_principal the provider runs as the instance it is on and no user credentials are needed.",
+		"retry": "(Optional) How failed API calls are retried: max_attempts, base_delay, max_delay, jitter and retryable_status_codes.\n" +
+			"Throttled calls (429) are always retried, after the wait given by their Retry-After header.",
+		"use_etags": "(Optional) Send the ETag seen at the last refresh as If-Match on updates and deletes, so that\n" +
+			"changes made outside Terraform in the meantime fail the apply instead of being overwritten.",
 	}
 }
 
 // Provider is the adapter for terraform, that gives access to all the resources
 func Provider(configfn schema.ConfigureFunc) terraform.ResourceProvider {
-	return &schema.Provider{
+	return &provider{&schema.Provider{
 		DataSourcesMap: dataSourcesMap(),
 		Schema:         schemaMap(),
 		ResourcesMap:   resourcesMap(),
 		ConfigureFunc:  configfn,
+	}}
+}
+
+// provider adds the checks of configValidators to the validation of
+// resources' schemas, so they run at plan time too.
+type provider struct {
+	*schema.Provider
+}
+
+// configValidator checks a resource's configuration as a whole, for problems a
+// ValidateFunc can't find because it only sees one attribute. The check is
+// skipped when any of attributes isn't known until apply.
+type configValidator struct {
+	attributes []string
+	validate   func(d *schema.ResourceData) error
+}
+
+func configValidators() map[string]configValidator {
+	return map[string]configValidator{
+		"baremetal_core_security_list": {
+			attributes: []string{"egress_security_rules", "ingress_security_rules"},
+			validate:   validateSecurityListConfig,
+		},
+		"baremetal_core_security_list_rule": {
+			attributes: []string{"direction", "protocol", "source", "destination", "icmp_options", "tcp_options", "udp_options", "stateless"},
+			validate:   validateSecurityListRuleConfig,
+		},
+	}
+}
+
+func (p *provider) ValidateResource(t string, c *terraform.ResourceConfig) (ws []string, es []error) {
+	if ws, es = p.Provider.ValidateResource(t, c); len(es) > 0 {
+		return
+	}
+	v, ok := configValidators()[t]
+	if !ok {
+		return
 	}
+	for _, k := range c.ComputedKeys {
+		for _, attribute := range v.attributes {
+			if k == attribute || strings.HasPrefix(k, attribute+".") {
+				return
+			}
+		}
+	}
+
+	// The configuration is read through the resource's schema, the way it
+	// is when the resource is applied.
+	r := p.ResourcesMap[t]
+	diff, err := r.Diff(nil, c)
+	if err != nil || diff == nil {
+		return
+	}
+	state := &terraform.InstanceState{Attributes: map[string]string{}}
+	for k, attr := range diff.Attributes {
+		if !attr.NewComputed && !attr.NewRemoved {
+			state.Attributes[k] = attr.New
+		}
+	}
+	if err = v.validate(r.Data(state)); err != nil {
+		es = append(es, err)
+	}
+	return
 }
 
 func schemaMap() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"tenancy_ocid": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["tenancy_ocid"],
 			DefaultFunc: schema.EnvDefaultFunc("OBMCS_TENANCY_OCID", nil),
 		},
 		"user_ocid": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["user_ocid"],
 			DefaultFunc: schema.EnvDefaultFunc("OBMCS_USER_OCID", nil),
 		},
 		"fingerprint": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Description: descriptions["fingerprint"],
 			DefaultFunc: schema.EnvDefaultFunc("OBMCS_FINGERPRINT", nil),
 		},
@@ -87,10 +169,35 @@ func schemaMap() map[string]*schema.Schema {
 		"region": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Default:     "us-phoenix-1",
 			Description: descriptions["region"],
 			DefaultFunc: schema.EnvDefaultFunc("OBMCS_REGION", nil),
 		},
+		"config_file_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: descriptions["config_file_path"],
+			DefaultFunc: schema.EnvDefaultFunc("OBMCS_CONFIG_FILE_PATH", nil),
+		},
+		"config_file_profile": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: descriptions["config_file_profile"],
+			DefaultFunc: schema.EnvDefaultFunc("OBMCS_CONFIG_FILE_PROFILE", defaultConfigFileProfile),
+		},
+		"auth": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  descriptions["auth"],
+			DefaultFunc:  schema.EnvDefaultFunc("OBMCS_AUTH", authAPIKey),
+			ValidateFunc: validation.StringInSlice([]string{authAPIKey, authInstancePrincipal}, false),
+		},
+		"retry": retrySchema(),
+		"use_etags": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: descriptions["use_etags"],
+			DefaultFunc: schema.EnvDefaultFunc("OBMCS_USE_ETAGS", false),
+		},
 	}
 }
 
@@ -101,6 +208,7 @@ func dataSourcesMap() map[string]*schema.Resource {
 		"baremetal_core_dhcp_options":               DHCPOptionsDatasource(),
 		"baremetal_core_drg_attachments":            DrgAttachmentDatasource(),
 		"baremetal_core_drgs":                       DrgDatasource(),
+		"baremetal_core_image":                      ImageLookupDatasource(),
 		"baremetal_core_images":                     ImageDatasource(),
 		"baremetal_core_instance_credentials":       InstanceCredentialsDatasource(),
 		"baremetal_core_instances":                  InstanceDatasource(),
@@ -111,11 +219,14 @@ func dataSourcesMap() map[string]*schema.Resource {
 		"baremetal_core_route_tables":               RouteTableDatasource(),
 		"baremetal_core_security_lists":             SecurityListDatasource(),
 		"baremetal_core_shape":                      InstanceShapeDatasource(),
+		"baremetal_core_subnet":                     SubnetLookupDatasource(),
 		"baremetal_core_subnets":                    SubnetDatasource(),
 		"baremetal_core_virtual_networks":           VirtualNetworkDatasource(),
 		"baremetal_core_vnic":                       VnicDatasource(),
 		"baremetal_core_vnic_attachments":           DatasourceCoreVnicAttachments(),
 		"baremetal_core_volume_attachments":         VolumeAttachmentDatasource(),
+		"baremetal_core_volume_backup":              VolumeBackupLookupDatasource(),
+		"baremetal_core_volume_backup_policy":       VolumeBackupPolicyDatasource(),
 		"baremetal_core_volume_backups":             VolumeBackupDatasource(),
 		"baremetal_core_volumes":                    VolumeDatasource(),
 		"baremetal_database_database":               DatabaseDatasource(),
@@ -129,6 +240,7 @@ func dataSourcesMap() map[string]*schema.Resource {
 		"baremetal_database_db_versions":            DBVersionDatasource(),
 		"baremetal_identity_api_keys":               APIKeyDatasource(),
 		"baremetal_identity_availability_domains":   AvailabilityDomainDatasource(),
+		"baremetal_identity_compartment":            CompartmentLookupDatasource(),
 		"baremetal_identity_compartments":           CompartmentDatasource(),
 		"baremetal_identity_groups":                 GroupDatasource(),
 		"baremetal_identity_policies":               IdentityPolicyDatasource(),
@@ -141,6 +253,7 @@ func dataSourcesMap() map[string]*schema.Resource {
 		"baremetal_load_balancer_policies":          LoadBalancerPolicyDatasource(),
 		"baremetal_load_balancer_protocols":         ProtocolDatasource(),
 		"baremetal_load_balancer_shapes":            LoadBalancerShapeDatasource(),
+		"baremetal_load_balancer_work_requests":     LoadBalancerWorkRequestDatasource(),
 		"baremetal_load_balancers":                  LoadBalancerDatasource(),
 		"baremetal_objectstorage_bucket_summaries":  BucketSummaryDatasource(),
 		"baremetal_objectstorage_namespace":         NamespaceDatasource(),
@@ -158,15 +271,22 @@ func resourcesMap() map[string]*schema.Resource {
 		"baremetal_core_drg_attachment":            DrgAttachmentResource(),
 		"baremetal_core_image":                     ImageResource(),
 		"baremetal_core_instance":                  InstanceResource(),
+		"baremetal_core_instance_action":           InstanceActionResource(),
 		"baremetal_core_internet_gateway":          InternetGatewayResource(),
 		"baremetal_core_ipsec":                     IPSecConnectionResource(),
 		"baremetal_core_route_table":               RouteTableResource(),
 		"baremetal_core_security_list":             SecurityListResource(),
+		"baremetal_core_security_list_rule":        SecurityListRuleResource(),
 		"baremetal_core_subnet":                    SubnetResource(),
 		"baremetal_core_virtual_network":           VirtualNetworkResource(),
 		"baremetal_core_volume":                    VolumeResource(),
 		"baremetal_core_volume_attachment":         VolumeAttachmentResource(),
 		"baremetal_core_volume_backup":             VolumeBackupResource(),
+		"baremetal_core_volume_backup_policy":      VolumeBackupPolicyResource(),
+		"baremetal_database_database":              DatabaseResource(),
+		"baremetal_database_db_home":               DBHomeResource(),
+		"baremetal_database_db_node":               DBNodeResource(),
+		"baremetal_database_db_node_action":        DBNodeActionResource(),
 		"baremetal_database_db_system":             DBSystemResource(),
 		"baremetal_identity_api_key":               APIKeyResource(),
 		"baremetal_identity_compartment":           CompartmentResource(),
@@ -211,17 +331,48 @@ func getRequiredEnvSetting(s string) string {
 }
 
 func providerConfig(d *schema.ResourceData) (client interface{}, err error) {
-	tenancyOCID := d.Get("tenancy_ocid").(string)
-	userOCID := d.Get("user_ocid").(string)
-	fingerprint := d.Get("fingerprint").(string)
+	configFile, err := readConfigFile(d.Get("config_file_path").(string), d.Get("config_file_profile").(string))
+	if err != nil {
+		return
+	}
+
+	// Explicit attributes and env vars take precedence over the config file.
+	setting := func(name string) string {
+		if v, ok := d.Get(name).(string); ok && v != "" {
+			return v
+		}
+		return configFile[name]
+	}
+
+	authMode := d.Get("auth").(string)
+	tenancyOCID := setting("tenancy_ocid")
+	userOCID := setting("user_ocid")
+	fingerprint := setting("fingerprint")
 	privateKeyBuffer, hasKey := d.Get("private_key").(string)
-	privateKeyPath, hasKeyPath := d.Get("private_key_path").(string)
-	privateKeyPassword, hasKeyPass := d.Get("private_key_password").(string)
-	region, hasRegion := d.Get("region").(string)
+	privateKeyPath := setting("private_key_path")
+	privateKeyPassword := setting("private_key_password")
+	region := setting("region")
+
+	if authMode != authInstancePrincipal {
+		for name, v := range map[string]string{"tenancy_ocid": tenancyOCID, "user_ocid": userOCID, "fingerprint": fingerprint} {
+			if v == "" {
+				err = fmt.Errorf("%s is required. Set it in the provider block, with OBMCS_%s or in the config file", name, strings.ToUpper(name))
+				return
+			}
+		}
+	}
+
+	settings := crud.Settings{UseETags: d.Get("use_etags").(bool)}
+	if settings.Retry, err = retryPolicy(d); err != nil {
+		return
+	}
 
 	// for internal use
 	urlTemplate := getEnvSetting("url_template", "")
 	allowInsecureTls := getEnvSetting("allow_insecure_tls", "")
+	httpReplayMode := getEnvSetting("http_replay_mode", "")
+	httpReplayFixture := getEnvSetting("http_replay_fixture", "")
+	instanceMetadataURL := getEnvSetting("instance_metadata_url", "")
 
 	clientOpts := []baremetal.NewClientOptionsFunc{
 		func(o *baremetal.NewClientOptions) {
@@ -229,31 +380,60 @@ func providerConfig(d *schema.ResourceData) (client interface{}, err error) {
 		},
 	}
 
+	var transport http.RoundTripper
 	if allowInsecureTls == "true" {
 		log.Println("[WARN] USING INSECURE TLS")
-		clientOpts = append(clientOpts, baremetal.CustomTransport(
-			&http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
-		))
+		transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
 	} else {
-		clientOpts = append(clientOpts, baremetal.CustomTransport(
-			&http.Transport{Proxy: http.ProxyFromEnvironment}),
-		)
+		transport = &http.Transport{Proxy: http.ProxyFromEnvironment}
 	}
 
-	if hasKey && privateKeyBuffer != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyBytes([]byte(privateKeyBuffer)))
-	} else if hasKeyPath && privateKeyPath != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyFilePath(privateKeyPath))
-	} else {
-		err = errors.New("One of private_key or private_key_path is required")
-		return
+	if httpReplayMode != "" {
+		log.Printf("[INFO] HTTP %s mode using %s", httpReplayMode, httpReplayFixture)
+		if transport, err = httpreplay.Open(httpReplayMode, httpReplayFixture, transport); err != nil {
+			return
+		}
+		if httpReplayMode == httpreplay.ModeReplay {
+			settings.PollInterval = time.Millisecond
+		}
 	}
+	clientOpts = append(clientOpts, baremetal.CustomTransport(transport))
 
-	if hasKeyPass && privateKeyPassword != "" {
-		clientOpts = append(clientOpts, baremetal.PrivateKeyPassword(privateKeyPassword))
+	switch authMode {
+	case authInstancePrincipal:
+		var signer *baremetal.InstancePrincipalSigner
+		signer, err = baremetal.NewInstancePrincipalSigner(&baremetal.InstancePrincipalOptions{
+			MetadataURL: instanceMetadataURL,
+			UrlTemplate: urlTemplate,
+			Transport:   transport,
+		})
+		if err != nil {
+			err = fmt.Errorf("Could not authenticate as an instance principal: %s", err)
+			return
+		}
+		clientOpts = append(clientOpts, baremetal.CustomSigner(signer))
+		if tenancyOCID == "" {
+			tenancyOCID = signer.TenancyOCID()
+		}
+		if region == "" {
+			region = signer.Region()
+		}
+	default:
+		if hasKey && privateKeyBuffer != "" {
+			clientOpts = append(clientOpts, baremetal.PrivateKeyBytes([]byte(privateKeyBuffer)))
+		} else if privateKeyPath != "" {
+			clientOpts = append(clientOpts, baremetal.PrivateKeyFilePath(privateKeyPath))
+		} else {
+			err = errors.New("One of private_key or private_key_path is required")
+			return
+		}
+
+		if privateKeyPassword != "" {
+			clientOpts = append(clientOpts, baremetal.PrivateKeyPassword(privateKeyPassword))
+		}
 	}
 
-	if hasRegion && region != "" {
+	if region != "" {
 		clientOpts = append(clientOpts, baremetal.Region(region))
 	}
 
@@ -261,6 +441,10 @@ func providerConfig(d *schema.ResourceData) (client interface{}, err error) {
 		clientOpts = append(clientOpts, baremetal.UrlTemplate(urlTemplate))
 	}
 
-	client, err = baremetal.NewClient(userOCID, tenancyOCID, fingerprint, clientOpts...)
+	api, err := baremetal.NewClient(userOCID, tenancyOCID, fingerprint, clientOpts...)
+	if err != nil {
+		return
+	}
+	client = &crud.Client{BareMetalClient: api, Settings: settings}
 	return
 }
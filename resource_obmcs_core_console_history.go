@@ -100,7 +100,7 @@ func (s *ConsoleHistoryResourceCrud) State() string {
 func (s *ConsoleHistoryResourceCrud) Create() (e error) {
 	instanceID := s.D.Get("instance_id").(string)
 
-	s.Res, e = s.Client.CaptureConsoleHistory(instanceID, nil)
+	s.Res, e = s.Client.CaptureConsoleHistory(instanceID, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 
 	return
 }
@@ -29,7 +29,7 @@ func (s *ResourceCoreConsoleHistoryTestSuite) SetupTest() {
 		return s.Client, nil
 	})
 
-	p := s.Provider.(*schema.Provider)
+	p := s.Provider.(*provider).Provider
 	res := p.ResourcesMap["baremetal_core_console_history"]
 	res.Delete = func(d *schema.ResourceData, m interface{}) (e error) {
 		return nil
@@ -21,6 +21,7 @@ func CpeResource() *schema.Resource {
 		Update:   updateCpe,
 		Delete:   deleteCpe,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -100,6 +101,7 @@ func (s *CpeResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Resource, e = s.Client.CreateCpe(compartmentID, ipAddress, opts)
 	return
 }
@@ -117,6 +119,7 @@ func (s *CpeResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Resource, e = s.Client.UpdateCpe(compartmentID, opts)
 	return
 }
@@ -129,5 +132,5 @@ func (s *CpeResourceCrud) SetData() {
 }
 
 func (s *CpeResourceCrud) Delete() (e error) {
-	return s.Client.DeleteCpe(s.D.Id(), nil)
+	return s.Client.DeleteCpe(s.D.Id(), s.IfMatchOptions())
 }
@@ -21,6 +21,7 @@ func DHCPOptionsResource() *schema.Resource {
 		Update:   updateDHCPOptions,
 		Delete:   deleteDHCPOptions,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -146,6 +147,7 @@ func (s *DHCPOptionsResourceCrud) Create() (e error) {
 	opts := &baremetal.CreateOptions{}
 	opts.DisplayName = s.D.Get("display_name").(string)
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateDHCPOptions(compartmentID, vcnID, s.buildEntities(), opts)
 
 	return
@@ -160,6 +162,7 @@ func (s *DHCPOptionsResourceCrud) Update() (e error) {
 	opts := &baremetal.UpdateDHCPDNSOptions{}
 	opts.Options = s.buildEntities()
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateDHCPOptions(s.D.Id(), opts)
 	return
 }
@@ -185,7 +188,7 @@ func (s *DHCPOptionsResourceCrud) SetData() {
 }
 
 func (s *DHCPOptionsResourceCrud) Delete() (e error) {
-	return s.Client.DeleteDHCPOptions(s.D.Id(), nil)
+	return s.Client.DeleteDHCPOptions(s.D.Id(), s.IfMatchOptions())
 }
 
 func (s *DHCPOptionsResourceCrud) buildEntities() (entities []baremetal.DHCPDNSOption) {
@@ -21,6 +21,7 @@ func DrgResource() *schema.Resource {
 		Update:   updateDrg,
 		Delete:   deleteDrg,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -112,6 +113,7 @@ func (s *DrgResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateDrg(compartmentID, opts)
 
 	return
@@ -130,6 +132,7 @@ func (s *DrgResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateDrg(compartmentID, opts)
 	return
 }
@@ -142,5 +145,5 @@ func (s *DrgResourceCrud) SetData() {
 }
 
 func (s *DrgResourceCrud) Delete() (e error) {
-	return s.Client.DeleteDrg(s.D.Id(), nil)
+	return s.Client.DeleteDrg(s.D.Id(), s.IfMatchOptions())
 }
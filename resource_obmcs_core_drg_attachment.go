@@ -21,6 +21,7 @@ func DrgAttachmentResource() *schema.Resource {
 		Update:   updateDrgAttachment,
 		Delete:   deleteDrgAttachment,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -127,6 +128,7 @@ func (s *DrgAttachmentResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateDrgAttachment(drgID, vcnID, opts)
 
 	return
@@ -145,6 +147,7 @@ func (s *DrgAttachmentResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateDrgAttachment(compartmentID, opts)
 	return
 }
@@ -159,5 +162,5 @@ func (s *DrgAttachmentResourceCrud) SetData() {
 }
 
 func (s *DrgAttachmentResourceCrud) Delete() (e error) {
-	return s.Client.DeleteDrgAttachment(s.D.Id(), nil)
+	return s.Client.DeleteDrgAttachment(s.D.Id(), s.IfMatchOptions())
 }
@@ -21,6 +21,7 @@ func ImageResource() *schema.Resource {
 		Update:   updateImage,
 		Delete:   deleteImage,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"base_image_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -139,6 +140,7 @@ func (s *ImageResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateImage(compartmentID, instanceID, opts)
 
 	return
@@ -156,6 +158,7 @@ func (s *ImageResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateImage(s.D.Id(), opts)
 
 	return
@@ -173,5 +176,5 @@ func (s *ImageResourceCrud) SetData() {
 }
 
 func (s *ImageResourceCrud) Delete() (e error) {
-	return s.Client.DeleteImage(s.D.Id(), nil)
+	return s.Client.DeleteImage(s.D.Id(), s.IfMatchOptions())
 }
@@ -3,10 +3,13 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
 	"github.com/oracle/terraform-provider-baremetal/options"
@@ -27,6 +30,7 @@ func InstanceResource() *schema.Resource {
 		Update: updateInstance,
 		Delete: deleteInstance,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"create_vnic_details": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -65,6 +69,12 @@ func InstanceResource() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"desired_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{baremetal.ResourceRunning, baremetal.ResourceStopped}, false),
+			},
 			"display_name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -134,7 +144,19 @@ func createInstance(d *schema.ResourceData, m interface{}) (e error) {
 	sync := &InstanceResourceCrud{}
 	sync.D = d
 	sync.Client = m.(client.BareMetalClient)
-	return crud.CreateResource(d, sync)
+
+	// Instances always launch running, stop them afterwards if asked to.
+	desiredState := d.Get("desired_state").(string)
+	if e = crud.CreateResource(d, sync); e != nil {
+		return
+	}
+	if desiredState == baremetal.ResourceStopped {
+		if e = sync.setPowerState(desiredState, d.Timeout(schema.TimeoutCreate)); e != nil {
+			return
+		}
+		sync.SetData()
+	}
+	return
 }
 
 func readInstance(d *schema.ResourceData, m interface{}) (e error) {
@@ -252,6 +274,7 @@ func (s *InstanceResourceCrud) Create() (e error) {
 		opts.CreateVnicOptions = vnicOpts
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Resource, e = s.Client.LaunchInstance(
 		availabilityDomain,
 		compartmentID,
@@ -334,15 +357,51 @@ func (s *InstanceResourceCrud) Get() (e error) {
 }
 
 func (s *InstanceResourceCrud) Update() (e error) {
-	opts := &baremetal.UpdateOptions{}
-	if displayName, ok := s.D.GetOk("display_name"); ok {
-		opts.DisplayName = displayName.(string)
+	if s.D.HasChange("display_name") {
+		opts := &baremetal.UpdateOptions{}
+		if displayName, ok := s.D.GetOk("display_name"); ok {
+			opts.DisplayName = displayName.(string)
+		}
+
+		opts.IfMatch = s.IfMatch()
+		if s.Resource, e = s.Client.UpdateInstance(s.D.Id(), opts); e != nil {
+			return
+		}
+		s.D.SetPartial("display_name")
+	}
+
+	if desiredState, ok := s.D.GetOk("desired_state"); ok && s.D.HasChange("desired_state") {
+		return s.setPowerState(desiredState.(string), s.D.Timeout(schema.TimeoutUpdate))
 	}
 
-	s.Resource, e = s.Client.UpdateInstance(s.D.Id(), opts)
+	if s.Resource == nil {
+		e = s.Get()
+	}
 	return
 }
 
+// setPowerState starts or stops the instance and waits until it is RUNNING
+// or STOPPED.
+func (s *InstanceResourceCrud) setPowerState(state string, timeout time.Duration) (e error) {
+	var action baremetal.InstanceActions
+	var pending []string
+	switch state {
+	case baremetal.ResourceRunning:
+		action = baremetal.InstanceActionStart
+		pending = []string{baremetal.ResourceStopped, baremetal.ResourceStarting}
+	case baremetal.ResourceStopped:
+		action = baremetal.InstanceActionStop
+		pending = []string{baremetal.ResourceRunning, baremetal.ResourceStopping}
+	default:
+		return fmt.Errorf("Instance %s can't be put in state %s", s.D.Id(), state)
+	}
+
+	if s.Resource, e = s.Client.InstanceAction(s.D.Id(), action, nil); e != nil {
+		return
+	}
+	return crud.WaitForState(s, timeout, pending, []string{state})
+}
+
 func (s *InstanceResourceCrud) SetData() {
 	s.D.Set("availability_domain", s.Resource.AvailabilityDomain)
 	s.D.Set("compartment_id", s.Resource.CompartmentID)
@@ -353,11 +412,15 @@ func (s *InstanceResourceCrud) SetData() {
 	s.D.Set("region", s.Resource.Region)
 	s.D.Set("shape", s.Resource.Shape)
 	s.D.Set("state", s.Resource.State)
+	// Only settled power states are desired states.
+	if s.Resource.State == baremetal.ResourceRunning || s.Resource.State == baremetal.ResourceStopped {
+		s.D.Set("desired_state", s.Resource.State)
+	}
 	s.D.Set("time_created", s.Resource.TimeCreated.String())
 	s.D.Set("public_ip", s.public_ip)
 	s.D.Set("private_ip", s.private_ip)
 }
 
 func (s *InstanceResourceCrud) Delete() (e error) {
-	return s.Client.TerminateInstance(s.D.Id(), nil)
+	return s.Client.TerminateInstance(s.D.Id(), s.IfMatchOptions())
 }
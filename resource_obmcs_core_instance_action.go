@@ -0,0 +1,120 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// InstanceActionResource resets an instance once when it is created. Changing
+// the instance, action or triggers resets it again. Its ID is
+// <instance_id>/<action>.
+func InstanceActionResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+		},
+		Create: createInstanceAction,
+		Read:   readInstanceAction,
+		Delete: deleteInstanceAction,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(baremetal.InstanceActionSoftReset),
+					string(baremetal.InstanceActionReset),
+				}, false),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createInstanceAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &InstanceActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readInstanceAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &InstanceActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func deleteInstanceAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &InstanceActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type InstanceActionResourceCrud struct {
+	crud.BaseCrud
+	Resource *baremetal.Instance
+}
+
+func (s *InstanceActionResourceCrud) ID() string {
+	return s.Resource.ID + "/" + s.D.Get("action").(string)
+}
+
+func (s *InstanceActionResourceCrud) CreatedPending() []string {
+	return []string{
+		baremetal.ResourceStopping,
+		baremetal.ResourceStopped,
+		baremetal.ResourceStarting,
+	}
+}
+
+func (s *InstanceActionResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceRunning}
+}
+
+func (s *InstanceActionResourceCrud) Create() (e error) {
+	action := baremetal.InstanceActions(s.D.Get("action").(string))
+	s.Resource, e = s.Client.InstanceAction(s.D.Get("instance_id").(string), action, nil)
+	return
+}
+
+// Get treats a terminated instance as gone, there's nothing left to reset.
+func (s *InstanceActionResourceCrud) Get() (e error) {
+	if s.Resource, e = s.Client.GetInstance(s.D.Get("instance_id").(string)); e != nil {
+		return
+	}
+	if s.Resource.State == baremetal.ResourceTerminated {
+		return crud.NewNotFoundError("Instance %s is terminated", s.Resource.ID)
+	}
+	return
+}
+
+func (s *InstanceActionResourceCrud) SetData() {
+	s.D.Set("instance_id", s.Resource.ID)
+	s.D.Set("state", s.Resource.State)
+}
+
+// Delete only forgets the action, the instance is left as it is.
+func (s *InstanceActionResourceCrud) Delete() (e error) {
+	return
+}
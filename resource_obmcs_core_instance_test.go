@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
 	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
@@ -78,6 +79,96 @@ func (s *ResourceCoreInstanceTestSuite) TestCreateResourceCoreInstance() {
 	})
 }
 
+// instancePowerConfig is a minimal instance in the given desired_state, with
+// extra resources appended.
+func instancePowerConfig(desiredState, extra string) string {
+	return subnetConfig + `
+data "baremetal_core_images" "t" {
+	compartment_id = "${var.compartment_id}"
+	limit = 1
+}
+
+data "baremetal_core_shape" "shapes" {
+	compartment_id = "${var.compartment_id}"
+	availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	image_id = "${data.baremetal_core_images.t.images.0.id}"
+}
+
+resource "baremetal_core_instance" "t" {
+	availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	compartment_id = "${var.compartment_id}"
+	display_name = "instance_name"
+	image = "${data.baremetal_core_images.t.images.0.id}"
+	shape = "${data.baremetal_core_shape.shapes.shapes.0.name}"
+	subnet_id = "${baremetal_core_subnet.WebSubnetAD1.id}"
+	desired_state = "` + desiredState + `"
+	metadata {
+		ssh_authorized_keys = "${var.ssh_public_key}"
+	}
+}
+` + extra + testProviderConfig()
+}
+
+func (s *ResourceCoreInstanceTestSuite) TestPowerActionsResourceCoreInstance() {
+	reset := `
+resource "baremetal_core_instance_action" "reset" {
+	instance_id = "${baremetal_core_instance.t.id}"
+	action = "SOFTRESET"
+}
+`
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: instancePowerConfig(baremetal.ResourceStopped, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceStopped),
+					resource.TestCheckResourceAttr(s.ResourceName, "desired_state", baremetal.ResourceStopped),
+				),
+			},
+			{
+				Config: instancePowerConfig(baremetal.ResourceRunning, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceRunning),
+					resource.TestCheckResourceAttr(s.ResourceName, "desired_state", baremetal.ResourceRunning),
+				),
+			},
+			{
+				Config: instancePowerConfig(baremetal.ResourceRunning, reset),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("baremetal_core_instance_action.reset", "instance_id", s.ResourceName, "id"),
+					resource.TestCheckResourceAttr("baremetal_core_instance_action.reset", "state", baremetal.ResourceRunning),
+					func(ts *terraform.State) error {
+						instanceID := ts.RootModule().Resources[s.ResourceName].Primary.ID
+						return resource.TestCheckResourceAttr("baremetal_core_instance_action.reset", "id", instanceID+"/SOFTRESET")(ts)
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestInstanceActionTerminatedInstance(t *testing.T) {
+	client := &mocks.BareMetalClient{}
+	instance := &baremetal.Instance{ID: "instance", State: baremetal.ResourceTerminated}
+	client.On("GetInstance", "instance").Return(instance, nil)
+
+	d := InstanceActionResource().Data(nil)
+	d.SetId("instance/SOFTRESET")
+	d.Set("instance_id", "instance")
+	d.Set("action", "SOFTRESET")
+	sync := &InstanceActionResourceCrud{}
+	sync.D = d
+	sync.Client = client
+
+	if e := crud.ReadResource(sync); e != nil {
+		t.Fatal(e)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected the action on a terminated instance to be gone, got ID %q", d.Id())
+	}
+}
+
 func TestIsStatefulResource(t *testing.T) {
 	var sr crud.StatefulResource
 	sr = &InstanceResourceCrud{}
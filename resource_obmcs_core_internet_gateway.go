@@ -21,6 +21,7 @@ func InternetGatewayResource() *schema.Resource {
 		Update:   updateInternetGateway,
 		Delete:   deleteInternetGateway,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -134,6 +135,7 @@ func (s *InternetGatewayResourceCrud) Create() (e error) {
 	opts := &baremetal.CreateOptions{}
 	opts.DisplayName = s.D.Get("display_name").(string)
 
+	opts.RetryToken = s.RetryToken
 	s.Resource, e = s.Client.CreateInternetGateway(compartmentID, vcnID, isEnabled, opts)
 	return
 }
@@ -152,6 +154,7 @@ func (s *InternetGatewayResourceCrud) Update() (e error) {
 		opts.DisplayName = name.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Resource, e = s.Client.UpdateInternetGateway(s.D.Id(), opts)
 	return
 }
@@ -166,5 +169,5 @@ func (s *InternetGatewayResourceCrud) SetData() {
 }
 
 func (s *InternetGatewayResourceCrud) Delete() (e error) {
-	return s.Client.DeleteInternetGateway(s.D.Id(), nil)
+	return s.Client.DeleteInternetGateway(s.D.Id(), s.IfMatchOptions())
 }
@@ -21,6 +21,7 @@ func IPSecConnectionResource() *schema.Resource {
 		Update:   updateIPSec,
 		Delete:   deleteIPSec,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -129,6 +130,7 @@ func (s *IPSecConnectionResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Resource, e = s.Client.CreateIPSecConnection(
 		compartmentID,
 		cpeID,
@@ -153,6 +155,7 @@ func (s *IPSecConnectionResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Resource, e = s.Client.UpdateIPSecConnection(compartmentID, opts)
 	return
 }
@@ -169,5 +172,5 @@ func (s *IPSecConnectionResourceCrud) SetData() {
 }
 
 func (s *IPSecConnectionResourceCrud) Delete() (e error) {
-	return s.Client.DeleteIPSecConnection(s.D.Id(), nil)
+	return s.Client.DeleteIPSecConnection(s.D.Id(), s.IfMatchOptions())
 }
@@ -3,10 +3,13 @@
 package main
 
 import (
+	"fmt"
+	"log"
+	"time"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
-
-	"time"
+	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
@@ -17,12 +20,15 @@ func RouteTableResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createRouteTable,
-		Read:     readRouteTable,
-		Update:   updateRouteTable,
-		Delete:   deleteRouteTable,
+		Timeouts:      crud.DefaultTimeout,
+		SchemaVersion: 1,
+		MigrateState:  migrateRouteTableState,
+		Create:        createRouteTable,
+		Read:          readRouteTable,
+		Update:        updateRouteTable,
+		Delete:        deleteRouteTable,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -38,8 +44,9 @@ func RouteTableResource() *schema.Resource {
 				Computed: true,
 			},
 			"route_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
+				Set:      routeRuleHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"cidr_block": {
@@ -74,6 +81,28 @@ func RouteTableResource() *schema.Resource {
 	}
 }
 
+// migrateRouteTableState moves the route rules of version 0 state, which
+// were a list, to a set.
+func migrateRouteTableState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is == nil || is.Empty() {
+		return is, nil
+	}
+
+	switch v {
+	case 0:
+		log.Printf("[INFO] Moving the route rules of route table %s to a set", is.ID)
+		migrateListToSet(is.Attributes, "route_rules", func(flat map[string]string) int {
+			return routeRuleHash(map[string]interface{}{
+				"cidr_block":        flat["cidr_block"],
+				"network_entity_id": flat["network_entity_id"],
+			})
+		})
+		return is, nil
+	default:
+		return is, fmt.Errorf("Unexpected schema version %d for route table %s", v, is.ID)
+	}
+}
+
 func createRouteTable(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(client.BareMetalClient)
 	crd := &RouteTableResourceCrud{}
@@ -142,6 +171,7 @@ func (s *RouteTableResourceCrud) Create() (e error) {
 	opts := &baremetal.CreateOptions{}
 	opts.DisplayName = s.D.Get("display_name").(string)
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateRouteTable(compartmentID, vcnID, s.buildRouteRules(), opts)
 
 	return
@@ -156,6 +186,7 @@ func (s *RouteTableResourceCrud) Update() (e error) {
 	opts := &baremetal.UpdateRouteTableOptions{}
 	opts.RouteRules = s.buildRouteRules()
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateRouteTable(s.D.Id(), opts)
 	return
 }
@@ -180,7 +211,7 @@ func (s *RouteTableResourceCrud) SetData() {
 }
 
 func (s *RouteTableResourceCrud) Delete() (e error) {
-	return s.Client.DeleteRouteTable(s.D.Id(), nil)
+	return s.Client.DeleteRouteTable(s.D.Id(), s.IfMatchOptions())
 }
 
 func (s *RouteTableResourceCrud) ExtraWaitPostCreateDelete() time.Duration {
@@ -189,7 +220,7 @@ func (s *RouteTableResourceCrud) ExtraWaitPostCreateDelete() time.Duration {
 
 func (s *RouteTableResourceCrud) buildRouteRules() (routeRules []baremetal.RouteRule) {
 	routeRules = []baremetal.RouteRule{}
-	for _, val := range s.D.Get("route_rules").([]interface{}) {
+	for _, val := range s.D.Get("route_rules").(*schema.Set).List() {
 		data := val.(map[string]interface{})
 		routeRule := baremetal.RouteRule{
 			CidrBlock:       data["cidr_block"].(string),
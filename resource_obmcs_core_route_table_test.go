@@ -3,6 +3,7 @@
 package main
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -80,8 +81,18 @@ func (s *ResourceCoreRouteTableTestSuite) TestCreateResourceCoreRouteTable() {
 				Check: resource.ComposeTestCheckFunc(
 
 					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "display_name"),
-					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.0.cidr_block", "0.0.0.0/0"),
-					resource.TestCheckResourceAttrSet(s.ResourceName, "route_rules.0.network_entity_id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "route_rules.#", "1"),
+					func(ts *terraform.State) error {
+						ig := ts.RootModule().Resources["baremetal_core_internet_gateway.CompleteIG"].Primary.ID
+						rule := fmt.Sprintf("route_rules.%d.", routeRuleHash(map[string]interface{}{
+							"cidr_block":        "0.0.0.0/0",
+							"network_entity_id": ig,
+						}))
+						return resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(s.ResourceName, rule+"cidr_block", "0.0.0.0/0"),
+							resource.TestCheckResourceAttr(s.ResourceName, rule+"network_entity_id", ig),
+						)(ts)
+					},
 				),
 			},
 		},
@@ -116,7 +127,10 @@ func (s ResourceCoreRouteTableTestSuite) TestUpdateRouteTable() {
 			},
 			{
 				Config: config,
-				Check:  resource.TestCheckResourceAttr(s.ResourceName, "route_rules.0.cidr_block", "new_cidr_block"),
+				Check: resource.TestCheckResourceAttr(s.ResourceName, fmt.Sprintf("route_rules.%d.cidr_block", routeRuleHash(map[string]interface{}{
+					"cidr_block":        "new_cidr_block",
+					"network_entity_id": "network_entity_id",
+				})), "new_cidr_block"),
 			},
 		},
 	})
@@ -3,8 +3,13 @@
 package main
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
@@ -17,12 +22,14 @@ var transportSchema = &schema.Schema{
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"max": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
 			},
 			"min": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
 			},
 		},
 	},
@@ -35,27 +42,35 @@ var icmpSchema = &schema.Schema{
 	Elem: &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"code": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
 			},
 			"type": {
-				Type:     schema.TypeInt,
-				Required: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
 			},
 		},
 	},
 }
 
+// SecurityListResource manages the rules of a security list. By default it
+// manages all of them, and removes rules that aren't in its configuration,
+// including those added by SecurityListRuleResource. With ignore_other_rules
+// set, it leaves those rules alone.
 func SecurityListResource() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		Timeouts: crud.DefaultTimeout,
-		Create:   createSecurityList,
-		Read:     readSecurityList,
-		Update:   updateSecurityList,
-		Delete:   deleteSecurityList,
+		Timeouts:      crud.DefaultTimeout,
+		SchemaVersion: 1,
+		MigrateState:  migrateSecurityListState,
+		Create:        createSecurityList,
+		Read:          readSecurityList,
+		Update:        updateSecurityList,
+		Delete:        deleteSecurityList,
 		Schema: map[string]*schema.Schema{
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -67,19 +82,24 @@ func SecurityListResource() *schema.Resource {
 				Computed: true,
 				Optional: true,
 			},
+			"etag": crud.ETagSchema,
 			"egress_security_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
+				Set:      egressRuleHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"destination": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.CIDRNetwork(0, 32),
 						},
 						"icmp_options": icmpSchema,
 						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateProtocol,
+							StateFunc:    normalizeProtocol,
 						},
 						"tcp_options": transportSchema,
 						"udp_options": transportSchema,
@@ -96,18 +116,22 @@ func SecurityListResource() *schema.Resource {
 				Computed: true,
 			},
 			"ingress_security_rules": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Required: true,
+				Set:      ingressRuleHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"icmp_options": icmpSchema,
 						"protocol": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateProtocol,
+							StateFunc:    normalizeProtocol,
 						},
 						"source": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.CIDRNetwork(0, 32),
 						},
 						"tcp_options": transportSchema,
 						"udp_options": transportSchema,
@@ -119,6 +143,21 @@ func SecurityListResource() *schema.Resource {
 					},
 				},
 			},
+			"ignore_other_rules": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"lint": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"lint_warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"state": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -136,6 +175,28 @@ func SecurityListResource() *schema.Resource {
 	}
 }
 
+// migrateSecurityListState moves the rules of version 0 state, which were
+// lists, to sets.
+func migrateSecurityListState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	if is == nil || is.Empty() {
+		return is, nil
+	}
+
+	switch v {
+	case 0:
+		log.Printf("[INFO] Moving the rules of security list %s to sets", is.ID)
+		migrateListToSet(is.Attributes, "egress_security_rules", func(flat map[string]string) int {
+			return egressRuleHash(flatSecurityRule(flat))
+		})
+		migrateListToSet(is.Attributes, "ingress_security_rules", func(flat map[string]string) int {
+			return ingressRuleHash(flatSecurityRule(flat))
+		})
+		return is, nil
+	default:
+		return is, fmt.Errorf("Unexpected schema version %d for security list %s", v, is.ID)
+	}
+}
+
 func createSecurityList(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(client.BareMetalClient)
 	crd := &SecurityListResourceCrud{}
@@ -199,13 +260,17 @@ func (s *SecurityListResourceCrud) State() string {
 
 func (s *SecurityListResourceCrud) Create() (e error) {
 	compartmentID := s.D.Get("compartment_id").(string)
-	egress := s.buildEgressRules()
-	ingress := s.buildIngressRules()
+	egress := buildEgressRules(s.D.Get("egress_security_rules").(*schema.Set).List())
+	ingress := buildIngressRules(s.D.Get("ingress_security_rules").(*schema.Set).List())
 	vcnID := s.D.Get("vcn_id").(string)
+	if e = s.checkRules(egress, ingress); e != nil {
+		return
+	}
 
 	opts := &baremetal.CreateOptions{}
 	opts.DisplayName = s.D.Get("display_name").(string)
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateSecurityList(compartmentID, vcnID, egress, ingress, opts)
 
 	return
@@ -216,17 +281,59 @@ func (s *SecurityListResourceCrud) Get() (e error) {
 	return
 }
 
+// Update replaces the security list's rules. With ignore_other_rules, it only
+// replaces the rules this resource manages and keeps the rest.
 func (s *SecurityListResourceCrud) Update() (e error) {
-	opts := &baremetal.UpdateSecurityListOptions{}
+	oldEgress, newEgress := s.D.GetChange("egress_security_rules")
+	oldIngress, newIngress := s.D.GetChange("ingress_security_rules")
+	if e = s.checkRules(buildEgressRules(newEgress.(*schema.Set).List()), buildIngressRules(newIngress.(*schema.Set).List())); e != nil {
+		return
+	}
+
+	ignoreOthers := s.D.Get("ignore_other_rules").(bool)
+	s.Res, e = updateSecurityListRules(s.Client, s.D.Id(), s.IfMatch(), func(list *baremetal.SecurityList) error {
+		egress := buildEgressRules(newEgress.(*schema.Set).List())
+		managed := egressRuleKeys(buildEgressRules(oldEgress.(*schema.Set).List()))
+		for _, rule := range list.EgressSecurityRules {
+			if ignoreOthers && !managed[egressRuleKey(rule)] {
+				egress = append(egress, rule)
+			}
+		}
+		list.EgressSecurityRules = egress
+
+		ingress := buildIngressRules(newIngress.(*schema.Set).List())
+		managed = ingressRuleKeys(buildIngressRules(oldIngress.(*schema.Set).List()))
+		for _, rule := range list.IngressSecurityRules {
+			if ignoreOthers && !managed[ingressRuleKey(rule)] {
+				ingress = append(ingress, rule)
+			}
+		}
+		list.IngressSecurityRules = ingress
+		return nil
+	})
+	return
+}
+
+// validateSecurityListConfig checks the rules at plan time. Rules with values
+// that weren't known then are checked by checkRules at apply time.
+func validateSecurityListConfig(d *schema.ResourceData) error {
+	return checkSecurityRules(
+		buildEgressRules(d.Get("egress_security_rules").(*schema.Set).List()),
+		buildIngressRules(d.Get("ingress_security_rules").(*schema.Set).List()),
+	)
+}
 
-	if egress := s.buildEgressRules(); egress != nil {
-		opts.EgressRules = egress
+// checkRules rejects invalid rules and, in lint mode, logs warnings about
+// the rules, before they are sent to the API.
+func (s *SecurityListResourceCrud) checkRules(egress []baremetal.EgressSecurityRule, ingress []baremetal.IngressSecurityRule) (e error) {
+	if e = checkSecurityRules(egress, ingress); e != nil {
+		return
 	}
-	if ingress := s.buildIngressRules(); ingress != nil {
-		opts.IngressRules = ingress
+	if s.D.Get("lint").(bool) {
+		for _, warning := range lintSecurityRules(egress, ingress) {
+			log.Printf("[WARN] Security list %s: %s", s.D.Get("display_name"), warning)
+		}
 	}
-
-	s.Res, e = s.Client.UpdateSecurityList(s.D.Id(), opts)
 	return
 }
 
@@ -234,8 +341,17 @@ func (s *SecurityListResourceCrud) SetData() {
 	s.D.Set("compartment_id", s.Res.CompartmentID)
 	s.D.Set("display_name", s.Res.DisplayName)
 
+	// With ignore_other_rules, only the rules in the configuration are
+	// tracked, the others belong to someone else.
+	managedEgress := egressRuleKeys(buildEgressRules(s.D.Get("egress_security_rules").(*schema.Set).List()))
+	managedIngress := ingressRuleKeys(buildIngressRules(s.D.Get("ingress_security_rules").(*schema.Set).List()))
+	managesAll := !s.D.Get("ignore_other_rules").(bool)
+
 	confEgressRules := []map[string]interface{}{}
 	for _, egressRule := range s.Res.EgressSecurityRules {
+		if !managesAll && !managedEgress[egressRuleKey(egressRule)] {
+			continue
+		}
 		confEgressRule := map[string]interface{}{}
 		confEgressRule["destination"] = egressRule.Destination
 		confEgressRule = buildConfRule(
@@ -252,6 +368,9 @@ func (s *SecurityListResourceCrud) SetData() {
 
 	confIngressRules := []map[string]interface{}{}
 	for _, ingressRule := range s.Res.IngressSecurityRules {
+		if !managesAll && !managedIngress[ingressRuleKey(ingressRule)] {
+			continue
+		}
 		confIngressRule := map[string]interface{}{}
 		confIngressRule["source"] = ingressRule.Source
 		confIngressRule = buildConfRule(
@@ -260,33 +379,45 @@ func (s *SecurityListResourceCrud) SetData() {
 			ingressRule.ICMPOptions,
 			ingressRule.TCPOptions,
 			ingressRule.UDPOptions,
-			nil,
+			&ingressRule.IsStateless,
 		)
 		confIngressRules = append(confIngressRules, confIngressRule)
 	}
 	s.D.Set("ingress_security_rules", confIngressRules)
 
+	warnings := []string{}
+	if s.D.Get("lint").(bool) {
+		warnings = lintSecurityRules(
+			buildEgressRules(s.D.Get("egress_security_rules").(*schema.Set).List()),
+			buildIngressRules(s.D.Get("ingress_security_rules").(*schema.Set).List()),
+		)
+	}
+	s.D.Set("ignore_other_rules", s.D.Get("ignore_other_rules"))
+	s.D.Set("lint", s.D.Get("lint"))
+	s.D.Set("lint_warnings", warnings)
+
 	s.D.Set("state", s.Res.State)
 	s.D.Set("time_created", s.Res.TimeCreated.String())
 	s.D.Set("vcn_id", s.Res.VcnID)
 }
 
 func (s *SecurityListResourceCrud) Delete() (e error) {
-	return s.Client.DeleteSecurityList(s.D.Id(), nil)
+	return s.Client.DeleteSecurityList(s.D.Id(), s.IfMatchOptions())
 }
 
-func (s *SecurityListResourceCrud) buildEgressRules() (sdkRules []baremetal.EgressSecurityRule) {
+func buildEgressRules(confRules []interface{}) (sdkRules []baremetal.EgressSecurityRule) {
 	sdkRules = []baremetal.EgressSecurityRule{}
-	for _, val := range s.D.Get("egress_security_rules").([]interface{}) {
+	for _, val := range confRules {
 		confRule := val.(map[string]interface{})
+		stateless, _ := confRule["stateless"].(bool)
 
 		sdkRule := baremetal.EgressSecurityRule{
 			Destination: confRule["destination"].(string),
-			ICMPOptions: s.buildICMPOptions(confRule),
-			Protocol:    confRule["protocol"].(string),
-			TCPOptions:  s.buildTCPOptions(confRule),
-			UDPOptions:  s.buildUDPOptions(confRule),
-			IsStateless: confRule["stateless"].(bool),
+			ICMPOptions: buildICMPOptions(confRule),
+			Protocol:    normalizeProtocol(confRule["protocol"]),
+			TCPOptions:  buildTCPOptions(confRule),
+			UDPOptions:  buildUDPOptions(confRule),
+			IsStateless: stateless,
 		}
 
 		sdkRules = append(sdkRules, sdkRule)
@@ -294,18 +425,19 @@ func (s *SecurityListResourceCrud) buildEgressRules() (sdkRules []baremetal.Egre
 	return
 }
 
-func (s *SecurityListResourceCrud) buildIngressRules() (sdkRules []baremetal.IngressSecurityRule) {
+func buildIngressRules(confRules []interface{}) (sdkRules []baremetal.IngressSecurityRule) {
 	sdkRules = []baremetal.IngressSecurityRule{}
-	for _, val := range s.D.Get("ingress_security_rules").([]interface{}) {
+	for _, val := range confRules {
 		confRule := val.(map[string]interface{})
+		stateless, _ := confRule["stateless"].(bool)
 
 		sdkRule := baremetal.IngressSecurityRule{
-			ICMPOptions: s.buildICMPOptions(confRule),
-			Protocol:    confRule["protocol"].(string),
+			ICMPOptions: buildICMPOptions(confRule),
+			Protocol:    normalizeProtocol(confRule["protocol"]),
 			Source:      confRule["source"].(string),
-			TCPOptions:  s.buildTCPOptions(confRule),
-			UDPOptions:  s.buildUDPOptions(confRule),
-			IsStateless: confRule["stateless"].(bool),
+			TCPOptions:  buildTCPOptions(confRule),
+			UDPOptions:  buildUDPOptions(confRule),
+			IsStateless: stateless,
 		}
 
 		sdkRules = append(sdkRules, sdkRule)
@@ -313,20 +445,21 @@ func (s *SecurityListResourceCrud) buildIngressRules() (sdkRules []baremetal.Ing
 	return
 }
 
-func (s *SecurityListResourceCrud) buildICMPOptions(conf map[string]interface{}) (opts *baremetal.ICMPOptions) {
-	l := conf["icmp_options"].([]interface{})
+func buildICMPOptions(conf map[string]interface{}) (opts *baremetal.ICMPOptions) {
+	l, _ := conf["icmp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
+		code, _ := confOpts["code"].(int)
 		opts = &baremetal.ICMPOptions{
-			Code: uint64(confOpts["code"].(int)),
+			Code: uint64(code),
 			Type: uint64(confOpts["type"].(int)),
 		}
 	}
 	return
 }
 
-func (s *SecurityListResourceCrud) buildTCPOptions(conf map[string]interface{}) (opts *baremetal.TCPOptions) {
-	l := conf["tcp_options"].([]interface{})
+func buildTCPOptions(conf map[string]interface{}) (opts *baremetal.TCPOptions) {
+	l, _ := conf["tcp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
 		opts = &baremetal.TCPOptions{
@@ -339,8 +472,8 @@ func (s *SecurityListResourceCrud) buildTCPOptions(conf map[string]interface{})
 	return
 }
 
-func (s *SecurityListResourceCrud) buildUDPOptions(conf map[string]interface{}) (opts *baremetal.UDPOptions) {
-	l := conf["udp_options"].([]interface{})
+func buildUDPOptions(conf map[string]interface{}) (opts *baremetal.UDPOptions) {
+	l, _ := conf["udp_options"].([]interface{})
 	if len(l) > 0 {
 		confOpts := l[0].(map[string]interface{})
 		opts = &baremetal.UDPOptions{
@@ -0,0 +1,322 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+var ruleTransportSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	ForceNew: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"max": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+			"min": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 65535),
+			},
+		},
+	},
+}
+
+var ruleICMPSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	ForceNew: true,
+	MaxItems: 1,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"code": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+			"type": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 255),
+			},
+		},
+	},
+}
+
+// SecurityListRuleResource adds a single rule to a security list that is
+// managed elsewhere. Each change reads the list, adds or removes the rule and
+// writes the list back, so rules from different configurations don't
+// overwrite each other.
+func SecurityListRuleResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importSecurityListRule,
+		},
+		Timeouts: crud.DefaultTimeout,
+		Create:   createSecurityListRule,
+		Read:     readSecurityListRule,
+		Delete:   deleteSecurityListRule,
+		Schema: map[string]*schema.Schema{
+			"security_list_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateProtocol,
+				StateFunc:    normalizeProtocol,
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.CIDRNetwork(0, 32),
+			},
+			"destination": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.CIDRNetwork(0, 32),
+			},
+			"icmp_options": ruleICMPSchema,
+			"tcp_options":  ruleTransportSchema,
+			"udp_options":  ruleTransportSchema,
+			"stateless": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+// importSecurityListRule imports a rule by the ID it was given when it was
+// created, <security_list_id>/<direction>/<hash>, finding out the rule from
+// the security list's rules.
+func importSecurityListRule(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || (parts[1] != "ingress" && parts[1] != "egress") {
+		return nil, fmt.Errorf("Import ID %q must be of the form <security_list_id>/<ingress|egress>/<hash>", d.Id())
+	}
+	listID, direction := parts[0], parts[1]
+	hash, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("Import ID %q has an invalid hash %q", d.Id(), parts[2])
+	}
+
+	list, err := m.(client.BareMetalClient).GetSecurityList(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conf map[string]interface{}
+	if direction == "ingress" {
+		for _, rule := range list.IngressSecurityRules {
+			if hashcode.String(ingressRuleKey(rule)) == hash {
+				conf = buildConfRule(map[string]interface{}{"source": rule.Source}, rule.Protocol, rule.ICMPOptions, rule.TCPOptions, rule.UDPOptions, &rule.IsStateless)
+			}
+		}
+	} else {
+		for _, rule := range list.EgressSecurityRules {
+			if hashcode.String(egressRuleKey(rule)) == hash {
+				conf = buildConfRule(map[string]interface{}{"destination": rule.Destination}, rule.Protocol, rule.ICMPOptions, rule.TCPOptions, rule.UDPOptions, &rule.IsStateless)
+			}
+		}
+	}
+	if conf == nil {
+		return nil, crud.NewNotFoundError("Security list %s has no %s rule %d", listID, direction, hash)
+	}
+
+	d.Set("security_list_id", listID)
+	d.Set("direction", direction)
+	for k, v := range conf {
+		d.Set(k, v)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func createSecurityListRule(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &SecurityListRuleResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readSecurityListRule(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &SecurityListRuleResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func deleteSecurityListRule(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &SecurityListRuleResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type SecurityListRuleResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.SecurityList
+}
+
+func (s *SecurityListRuleResourceCrud) ID() string {
+	return fmt.Sprintf("%s/%s/%d", s.D.Get("security_list_id").(string), s.D.Get("direction").(string), hashcode.String(s.key()))
+}
+
+func (s *SecurityListRuleResourceCrud) Create() (e error) {
+	if e = s.checkRule(); e != nil {
+		return
+	}
+
+	direction := s.D.Get("direction").(string)
+	id := s.D.Get("security_list_id").(string)
+	s.Res, e = updateSecurityListRules(s.Client, id, "", func(list *baremetal.SecurityList) error {
+		if s.hasRule(list) {
+			return fmt.Errorf("Security list %s already has the rule %s", id, s.key())
+		}
+		if direction == "ingress" {
+			list.IngressSecurityRules = append(list.IngressSecurityRules, s.ingressRule())
+		} else {
+			list.EgressSecurityRules = append(list.EgressSecurityRules, s.egressRule())
+		}
+		return nil
+	})
+	return
+}
+
+// validateSecurityListRuleConfig checks the rule at plan time. A rule with
+// values that weren't known then is checked when it's created.
+func validateSecurityListRuleConfig(d *schema.ResourceData) error {
+	sync := &SecurityListRuleResourceCrud{}
+	sync.D = d
+	return sync.checkRule()
+}
+
+// checkRule rejects a rule with the wrong CIDR blocks for its direction, and
+// the problems checkSecurityRules finds.
+func (s *SecurityListRuleResourceCrud) checkRule() error {
+	direction := s.D.Get("direction").(string)
+	source := s.D.Get("source").(string)
+	destination := s.D.Get("destination").(string)
+	switch {
+	case direction == "ingress" && (source == "" || destination != ""):
+		return fmt.Errorf("Ingress rules need a source and no destination")
+	case direction == "egress" && (destination == "" || source != ""):
+		return fmt.Errorf("Egress rules need a destination and no source")
+	}
+
+	if direction == "ingress" {
+		return checkSecurityRules(nil, []baremetal.IngressSecurityRule{s.ingressRule()})
+	}
+	return checkSecurityRules([]baremetal.EgressSecurityRule{s.egressRule()}, nil)
+}
+
+func (s *SecurityListRuleResourceCrud) Get() (e error) {
+	s.Res, e = s.Client.GetSecurityList(s.D.Get("security_list_id").(string))
+	return
+}
+
+// SetData only finds out whether the rule is still there, everything else
+// about it is in its configuration. A rule whose security list is gone is
+// gone too.
+func (s *SecurityListRuleResourceCrud) SetData() {
+	if s.Res == nil || !s.hasRule(s.Res) {
+		s.D.SetId("")
+	}
+}
+
+func (s *SecurityListRuleResourceCrud) Delete() (e error) {
+	key := s.key()
+	_, e = updateSecurityListRules(s.Client, s.D.Get("security_list_id").(string), "", func(list *baremetal.SecurityList) error {
+		egress := []baremetal.EgressSecurityRule{}
+		for _, rule := range list.EgressSecurityRules {
+			if egressRuleKey(rule) != key {
+				egress = append(egress, rule)
+			}
+		}
+		list.EgressSecurityRules = egress
+
+		ingress := []baremetal.IngressSecurityRule{}
+		for _, rule := range list.IngressSecurityRules {
+			if ingressRuleKey(rule) != key {
+				ingress = append(ingress, rule)
+			}
+		}
+		list.IngressSecurityRules = ingress
+		return nil
+	})
+	return
+}
+
+func (s *SecurityListRuleResourceCrud) conf() map[string]interface{} {
+	return map[string]interface{}{
+		"destination":  s.D.Get("destination"),
+		"icmp_options": s.D.Get("icmp_options"),
+		"protocol":     s.D.Get("protocol"),
+		"source":       s.D.Get("source"),
+		"stateless":    s.D.Get("stateless"),
+		"tcp_options":  s.D.Get("tcp_options"),
+		"udp_options":  s.D.Get("udp_options"),
+	}
+}
+
+func (s *SecurityListRuleResourceCrud) egressRule() baremetal.EgressSecurityRule {
+	return buildEgressRules([]interface{}{s.conf()})[0]
+}
+
+func (s *SecurityListRuleResourceCrud) ingressRule() baremetal.IngressSecurityRule {
+	return buildIngressRules([]interface{}{s.conf()})[0]
+}
+
+func (s *SecurityListRuleResourceCrud) key() string {
+	if s.D.Get("direction").(string) == "ingress" {
+		return ingressRuleKey(s.ingressRule())
+	}
+	return egressRuleKey(s.egressRule())
+}
+
+func (s *SecurityListRuleResourceCrud) hasRule(list *baremetal.SecurityList) bool {
+	key := s.key()
+	for _, rule := range list.IngressSecurityRules {
+		if ingressRuleKey(rule) == key {
+			return true
+		}
+	}
+	for _, rule := range list.EgressSecurityRules {
+		if egressRuleKey(rule) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceCoreSecurityListRuleTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreSecurityListRuleTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+
+	s.Provider = Provider(
+		func(d *schema.ResourceData) (interface{}, error) {
+			return s.Client, nil
+		},
+	)
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+
+	s.Config = `
+	resource "baremetal_core_virtual_network" "t" {
+	  cidr_block = "10.0.0.0/16"
+	  compartment_id = "${var.compartment_id}"
+	  display_name = "display_name"
+	}
+	` + testProviderConfig()
+
+	s.ResourceName = "baremetal_core_security_list.t"
+}
+
+func (s *ResourceCoreSecurityListRuleTestSuite) securityList(ingress string) string {
+	return `
+	resource "baremetal_core_security_list" "t" {
+	  compartment_id = "${var.compartment_id}"
+	  vcn_id = "${baremetal_core_virtual_network.t.id}"
+	  ignore_other_rules = true
+	  egress_security_rules = [{
+	    destination = "0.0.0.0/0"
+	    protocol = "all"
+	  }]
+	  ingress_security_rules = [` + ingress + `]
+	}
+	`
+}
+
+// checkRules checks the security list has the given numbers of rules in the
+// API, whatever its resource tracks.
+func (s *ResourceCoreSecurityListRuleTestSuite) checkRules(egress, ingress int) resource.TestCheckFunc {
+	return func(ts *terraform.State) error {
+		list, err := s.Client.GetSecurityList(ts.RootModule().Resources[s.ResourceName].Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(list.EgressSecurityRules) != egress || len(list.IngressSecurityRules) != ingress {
+			return fmt.Errorf("Expected %d egress and %d ingress rules, got %v", egress, ingress, list)
+		}
+		return nil
+	}
+}
+
+func (s *ResourceCoreSecurityListRuleTestSuite) TestMergeRules() {
+	ssh := `{
+	    protocol = "6"
+	    source = "10.0.0.0/16"
+	    tcp_options {
+	      min = 22
+	      max = 22
+	    }
+	  }`
+	http := `
+	resource "baremetal_core_security_list_rule" "http" {
+	  security_list_id = "${baremetal_core_security_list.t.id}"
+	  direction = "ingress"
+	  protocol = "6"
+	  source = "0.0.0.0/0"
+	  tcp_options {
+	    min = 80
+	    max = 80
+	  }
+	}
+	`
+	https := `
+	resource "baremetal_core_security_list_rule" "https" {
+	  security_list_id = "${baremetal_core_security_list.t.id}"
+	  direction = "ingress"
+	  protocol = "6"
+	  source = "0.0.0.0/0"
+	  tcp_options {
+	    min = 443
+	    max = 443
+	  }
+	}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			// The list keeps its own rule and ignores the ones added by the
+			// rule resources.
+			{
+				Config: s.Config + s.securityList(ssh) + http + https,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.min", ingressRuleHash(map[string]interface{}{
+						"protocol":    "6",
+						"source":      "10.0.0.0/16",
+						"tcp_options": []interface{}{map[string]interface{}{"max": 22, "min": 22}},
+					})), "22"),
+					resource.TestCheckResourceAttrSet("baremetal_core_security_list_rule.http", "id"),
+					s.checkRules(1, 3),
+				),
+			},
+			// Rules are imported by the ID they were created with.
+			{
+				ResourceName:      "baremetal_core_security_list_rule.http",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "baremetal_core_security_list_rule.http",
+				ImportState:   true,
+				ImportStateId: "ocid1.securitylist.oc1.phx.aaaa/sideways/1",
+				ExpectError:   regexp.MustCompile("must be of the form"),
+			},
+			// Changing the list's rules keeps the others.
+			{
+				Config: s.Config + s.securityList("") + http + https,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "0"),
+					s.checkRules(1, 2),
+				),
+			},
+			// Removing a rule resource only removes its rule.
+			{
+				Config: s.Config + s.securityList(ssh) + http,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "1"),
+					s.checkRules(1, 2),
+				),
+			},
+		},
+	})
+}
+
+// Without ignore_other_rules, the security list tracks every rule, so a
+// security list and a rule resource on it remove each other's rules.
+func (s *ResourceCoreSecurityListRuleTestSuite) TestOtherRulesTrackedByDefault() {
+	list := func(ignoreOtherRules string) string {
+		return s.Config + `
+	resource "baremetal_core_security_list" "t" {
+	  compartment_id = "${var.compartment_id}"
+	  vcn_id = "${baremetal_core_virtual_network.t.id}"
+	  ignore_other_rules = ` + ignoreOtherRules + `
+	  egress_security_rules = []
+	  ingress_security_rules = []
+	}
+	resource "baremetal_core_security_list_rule" "t" {
+	  security_list_id = "${baremetal_core_security_list.t.id}"
+	  direction = "ingress"
+	  protocol = "6"
+	  source = "0.0.0.0/0"
+	}
+	`
+	}
+	config := list("false")
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				Check:              s.checkRules(0, 1),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:             config,
+				Check:              resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Applying removes the rule resource's rule, which it then
+			// plans to add again.
+			{
+				Config:             config,
+				Check:              s.checkRules(0, 0),
+				ExpectNonEmptyPlan: true,
+			},
+			// With ignore_other_rules, both keep their rules.
+			{
+				Config: list("true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "0"),
+					s.checkRules(0, 1),
+				),
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreSecurityListRuleTestSuite) TestRejectDuplicateRule() {
+	config := s.Config + s.securityList(`{
+	    protocol = "6"
+	    source = "0.0.0.0/0"
+	  }`) + `
+	resource "baremetal_core_security_list_rule" "t" {
+	  security_list_id = "${baremetal_core_security_list.t.id}"
+	  direction = "ingress"
+	  protocol = "6"
+	  source = "0.0.0.0/0"
+	}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("already has the rule"),
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreSecurityListRuleTestSuite) TestInvalidRules() {
+	rule := func(attributes string) string {
+		return s.Config + s.securityList("") + `
+	resource "baremetal_core_security_list_rule" "t" {
+	  security_list_id = "${baremetal_core_security_list.t.id}"
+	  ` + attributes + `
+	}
+	`
+	}
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      rule(`direction = "ingress", protocol = "6", destination = "0.0.0.0/0"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Ingress rules need a source and no destination"),
+			},
+			{
+				Config:      rule(`direction = "ingress", protocol = "6", source = "0.0.0.0/0", tcp_options { min = 443, max = 80 }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("min can't be more than max"),
+			},
+			{
+				Config:      rule(`direction = "egress", protocol = "udp", destination = "0.0.0.0/0", tcp_options { min = 53, max = 53 }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("protocol isn't tcp"),
+			},
+		},
+	})
+}
+
+func TestResourceCoreSecurityListRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreSecurityListRuleTestSuite))
+}
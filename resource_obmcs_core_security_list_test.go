@@ -3,6 +3,8 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
 	"github.com/stretchr/testify/suite"
 )
@@ -109,8 +112,15 @@ func (s *ResourceCoreSecurityListTestSuite) TestCreateResourceCoreSecurityList()
 					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "Public"),
 					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.#", "1"),
 					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "2"),
-					resource.TestCheckResourceAttr(s.ResourceName, "egress_security_rules.0.stateless", "false"),
-					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.0.tcp_options.0.max", "80"),
+					resource.TestCheckResourceAttr(s.ResourceName, fmt.Sprintf("egress_security_rules.%d.stateless", egressRuleHash(map[string]interface{}{
+						"destination": "0.0.0.0/0",
+						"protocol":    "6",
+					})), "false"),
+					resource.TestCheckResourceAttr(s.ResourceName, fmt.Sprintf("ingress_security_rules.%d.tcp_options.0.max", ingressRuleHash(map[string]interface{}{
+						"protocol":    "6",
+						"source":      "0.0.0.0/0",
+						"tcp_options": []interface{}{map[string]interface{}{"max": 80, "min": 80}},
+					})), "80"),
 				),
 			},
 		},
@@ -147,6 +157,195 @@ func (s *ResourceCoreSecurityListTestSuite) TestCreateResourceCoreSecurityListRe
 	})
 }
 
+// The API returning the rules in another order doesn't change the plan.
+func (s *ResourceCoreSecurityListTestSuite) TestReorderedRules() {
+	var id string
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + s.SLConfig,
+				Check: func(ts *terraform.State) error {
+					id = ts.RootModule().Resources[s.ResourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					list, err := s.Client.GetSecurityList(id)
+					s.Require().NoError(err)
+					ingress := list.IngressSecurityRules
+					ingress[0], ingress[1] = ingress[1], ingress[0]
+					opts := &baremetal.UpdateSecurityListOptions{
+						EgressRules:  list.EgressSecurityRules,
+						IngressRules: ingress,
+					}
+					_, err = s.Client.UpdateSecurityList(id, opts)
+					s.Require().NoError(err)
+				},
+				Config:   s.Config + s.SLConfig,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreSecurityListTestSuite) TestInvalidRules() {
+	list := func(ingress string) string {
+		return s.Config + `
+			resource "baremetal_core_security_list" "t" {
+			    compartment_id = "${var.compartment_id}"
+			    vcn_id = "${baremetal_core_virtual_network.t.id}"
+			    egress_security_rules = []
+			    ingress_security_rules = [` + ingress + `]
+			}
+		`
+	}
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      list(`{ protocol = "sctp", source = "0.0.0.0/0" }`),
+				ExpectError: regexp.MustCompile("must be a protocol number"),
+			},
+			{
+				Config:      list(`{ protocol = "6", source = "10.0.0.0/33" }`),
+				ExpectError: regexp.MustCompile("10.0.0.0/33"),
+			},
+			{
+				Config:      list(`{ protocol = "tcp", source = "0.0.0.0/0", tcp_options { min = 443, max = 80 } }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("min can't be more than max"),
+			},
+			{
+				Config:      list(`{ protocol = "udp", source = "0.0.0.0/0", tcp_options { min = 53, max = 53 } }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("protocol isn't tcp"),
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreSecurityListTestSuite) TestLintWarnings() {
+	config := s.Config + `
+		resource "baremetal_core_security_list" "t" {
+		    compartment_id = "${var.compartment_id}"
+		    vcn_id = "${baremetal_core_virtual_network.t.id}"
+		    lint = true
+		    egress_security_rules = []
+		    ingress_security_rules = [{
+		        protocol = "tcp"
+		        source = "0.0.0.0/0"
+		        tcp_options {
+		            min = 22
+		            max = 22
+		        }
+		    },
+		    {
+		        protocol = "all"
+		        source = "10.0.0.0/16"
+		    },
+		    {
+		        protocol = "6"
+		        source = "10.0.1.0/24"
+		    }]
+		}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "3"),
+					resource.TestCheckResourceAttr(s.ResourceName, "lint_warnings.#", "2"),
+					resource.TestMatchResourceAttr(s.ResourceName, "lint_warnings.0", regexp.MustCompile("is shadowed by")),
+					resource.TestMatchResourceAttr(s.ResourceName, "lint_warnings.1", regexp.MustCompile("opens port 22")),
+				),
+			},
+			// Protocol names are stored as numbers, so they don't cause diffs.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// concurrentEditClient adds a rule to a security list right before the
+// provider's first update of it, as someone editing the list at the same time
+// would.
+type concurrentEditClient struct {
+	client.BareMetalClient
+	edited bool
+}
+
+func (c *concurrentEditClient) UpdateSecurityList(id string, opts *baremetal.UpdateSecurityListOptions) (*baremetal.SecurityList, error) {
+	if !c.edited {
+		c.edited = true
+		list, err := c.BareMetalClient.GetSecurityList(id)
+		if err != nil {
+			return nil, err
+		}
+		edit := &baremetal.UpdateSecurityListOptions{
+			EgressRules:  list.EgressSecurityRules,
+			IngressRules: append(list.IngressSecurityRules, baremetal.IngressSecurityRule{Protocol: "17", Source: "10.1.0.0/16"}),
+		}
+		if _, err = c.BareMetalClient.UpdateSecurityList(id, edit); err != nil {
+			return nil, err
+		}
+	}
+	return c.BareMetalClient.UpdateSecurityList(id, opts)
+}
+
+func (s *ResourceCoreSecurityListTestSuite) TestChangedSinceRefresh() {
+	api := &crud.Client{
+		BareMetalClient: &concurrentEditClient{BareMetalClient: s.Client},
+		Settings:        crud.Settings{UseETags: true},
+	}
+	providers := map[string]terraform.ResourceProvider{
+		"baremetal": Provider(func(d *schema.ResourceData) (interface{}, error) {
+			return api, nil
+		}),
+	}
+	config := s.Config + `
+		resource "baremetal_core_security_list" "t" {
+		    compartment_id = "${var.compartment_id}"
+		    display_name = "Public"
+		    vcn_id = "${baremetal_core_virtual_network.t.id}"
+		    egress_security_rules = []
+		    ingress_security_rules = [{
+		        protocol = "6"
+		        source = "10.0.0.0/16"
+		    }]
+		}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config + s.SLConfig,
+				Check:  resource.TestCheckResourceAttrSet(s.ResourceName, "etag"),
+			},
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("was changed outside Terraform since it was last refreshed"),
+			},
+			// Once refreshed, the update goes through.
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(s.ResourceName, "etag"),
+					resource.TestCheckResourceAttr(s.ResourceName, "ingress_security_rules.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func (s *ResourceCoreSecurityListTestSuite) TestDeleteSecurityList() {
 
 	resource.UnitTest(s.T(), resource.TestCase{
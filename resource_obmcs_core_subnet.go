@@ -23,6 +23,7 @@ func SubnetResource() *schema.Resource {
 		Update:   updateSubnet,
 		Delete:   deleteSubnet,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"availability_domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -197,6 +198,7 @@ func (s *SubnetResourceCrud) Create() (e error) {
 		opts.RouteTableID = routeTableID.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Resource, e = s.Client.CreateSubnet(
 		availabilityDomain,
 		cidrBlock,
@@ -221,6 +223,7 @@ func (s *SubnetResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Resource, e = s.Client.UpdateSubnet(compartmentID, opts)
 	return
 }
@@ -243,7 +246,7 @@ func (s *SubnetResourceCrud) SetData() {
 }
 
 func (s *SubnetResourceCrud) Delete() (e error) {
-	return s.Client.DeleteSubnet(s.D.Id(), nil)
+	return s.Client.DeleteSubnet(s.D.Id(), s.IfMatchOptions())
 }
 
 // makeSetFromStrings encodes an []string into a
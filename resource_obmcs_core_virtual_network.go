@@ -21,6 +21,7 @@ func VirtualNetworkResource() *schema.Resource {
 		Update:   updateVirtualNetwork,
 		Delete:   deleteVirtualNetwork,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"cidr_block": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -140,6 +141,7 @@ func (s *VirtualNetworkResourceCrud) Create() (e error) {
 		opts.DnsLabel = dnsLabel.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateVirtualNetwork(cidrBlock, compartmentID, opts)
 
 	return
@@ -158,6 +160,7 @@ func (s *VirtualNetworkResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateVirtualNetwork(compartmentID, opts)
 	return
 }
@@ -175,5 +178,5 @@ func (s *VirtualNetworkResourceCrud) SetData() {
 }
 
 func (s *VirtualNetworkResourceCrud) Delete() (e error) {
-	return s.Client.DeleteVirtualNetwork(s.D.Id(), nil)
+	return s.Client.DeleteVirtualNetwork(s.D.Id(), s.IfMatchOptions())
 }
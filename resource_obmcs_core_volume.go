@@ -3,8 +3,11 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
@@ -21,6 +24,7 @@ func VolumeResource() *schema.Resource {
 		Update:   updateVolume,
 		Delete:   deleteVolume,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"availability_domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -34,7 +38,7 @@ func VolumeResource() *schema.Resource {
 			"size_in_mbs": {
 				Type:     schema.TypeInt,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 			},
 			"display_name": {
 				Type:     schema.TypeString,
@@ -54,6 +58,32 @@ func VolumeResource() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"source_details": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"volume_backup_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(baremetal.VolumeSourceFromVolumeBackup),
+								string(baremetal.VolumeSourceFromVolume),
+							}, false),
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
 			"time_created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -104,13 +134,25 @@ func (s *VolumeResourceCrud) ID() string {
 }
 
 func (s *VolumeResourceCrud) CreatedPending() []string {
-	return []string{baremetal.ResourceProvisioning}
+	return []string{
+		baremetal.ResourceProvisioning,
+		baremetal.ResourceRestoring,
+	}
 }
 
 func (s *VolumeResourceCrud) CreatedTarget() []string {
 	return []string{baremetal.ResourceAvailable}
 }
 
+// UpdatedPending covers a resize, the volume stays attached throughout.
+func (s *VolumeResourceCrud) UpdatedPending() []string {
+	return []string{baremetal.ResourceProvisioning}
+}
+
+func (s *VolumeResourceCrud) UpdatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
 func (s *VolumeResourceCrud) DeletedPending() []string {
 	return []string{baremetal.ResourceTerminating}
 }
@@ -140,24 +182,58 @@ func (s *VolumeResourceCrud) Create() (e error) {
 	if ok {
 		opts.VolumeBackupID = volumeBackupID.(string)
 	}
+	if rawSource, ok := s.D.GetOk("source_details"); ok {
+		source := rawSource.([]interface{})[0].(map[string]interface{})
+		opts.SourceDetails = &baremetal.VolumeSourceDetails{
+			Type: baremetal.VolumeSourceType(source["type"].(string)),
+			ID:   source["id"].(string),
+		}
+		if opts.SourceDetails.Type == baremetal.VolumeSourceFromVolume {
+			if e = s.checkCloneSource(opts.SourceDetails.ID, availabilityDomain); e != nil {
+				return
+			}
+		}
+	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateVolume(availabilityDomain, compartmentID, opts)
 
 	return
 }
 
+// checkCloneSource makes sure the volume to clone is in the availability
+// domain of the clone. Volumes only move to another one through a backup.
+func (s *VolumeResourceCrud) checkCloneSource(sourceID, availabilityDomain string) (e error) {
+	var source *baremetal.Volume
+	if source, e = s.Client.GetVolume(sourceID); e != nil {
+		return
+	}
+	if source.AvailabilityDomain != availabilityDomain {
+		return fmt.Errorf("Volume %s is in %s and can't be cloned into %s, restore it from a volume backup instead", sourceID, source.AvailabilityDomain, availabilityDomain)
+	}
+	return
+}
+
 func (s *VolumeResourceCrud) Get() (e error) {
 	s.Res, e = s.Client.GetVolume(s.D.Id())
 	return
 }
 
 func (s *VolumeResourceCrud) Update() (e error) {
-	opts := &baremetal.UpdateOptions{}
+	opts := &baremetal.UpdateVolumeOptions{}
 	displayName, ok := s.D.GetOk("display_name")
 	if ok {
 		opts.DisplayName = displayName.(string)
 	}
+	if s.D.HasChange("size_in_mbs") {
+		oldSize, newSize := s.D.GetChange("size_in_mbs")
+		if newSize.(int) < oldSize.(int) {
+			return fmt.Errorf("Volume %s can't shrink from %d to %d MBs, volumes can only grow", s.D.Id(), oldSize.(int), newSize.(int))
+		}
+		opts.SizeInMBs = newSize.(int)
+	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateVolume(s.D.Id(), opts)
 
 	return
@@ -168,10 +244,18 @@ func (s *VolumeResourceCrud) SetData() {
 	s.D.Set("compartment_id", s.Res.CompartmentID)
 	s.D.Set("display_name", s.Res.DisplayName)
 	s.D.Set("size_in_mbs", s.Res.SizeInMBs)
+	sourceDetails := []interface{}{}
+	if s.Res.SourceDetails != nil {
+		sourceDetails = append(sourceDetails, map[string]interface{}{
+			"type": string(s.Res.SourceDetails.Type),
+			"id":   s.Res.SourceDetails.ID,
+		})
+	}
+	s.D.Set("source_details", sourceDetails)
 	s.D.Set("state", s.Res.State)
 	s.D.Set("time_created", s.Res.TimeCreated.String())
 }
 
 func (s *VolumeResourceCrud) Delete() (e error) {
-	return s.Client.DeleteVolume(s.D.Id(), nil)
+	return s.Client.DeleteVolume(s.D.Id(), s.IfMatchOptions())
 }
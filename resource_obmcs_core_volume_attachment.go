@@ -3,6 +3,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -11,6 +13,16 @@ import (
 	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
+var volumeAttachmentTypes = []string{
+	baremetal.VolumeAttachmentISCSI,
+	baremetal.VolumeAttachmentParavirtualized,
+	baremetal.VolumeAttachmentEmulated,
+}
+
+// VolumeAttachmentResource attaches a volume to an instance over iSCSI, as a
+// paravirtualized device or as an emulated device. Only iSCSI attachments
+// have target details, and only they can generate the iscsiadm commands to
+// run on the instance.
 func VolumeAttachmentResource() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
@@ -19,6 +31,7 @@ func VolumeAttachmentResource() *schema.Resource {
 		Timeouts: crud.DefaultTimeout,
 		Create:   createVolumeAttachment,
 		Read:     readVolumeAttachment,
+		Update:   updateVolumeAttachment,
 		Delete:   deleteVolumeAttachment,
 		Schema: map[string]*schema.Schema{
 			//// Required ////
@@ -26,7 +39,7 @@ func VolumeAttachmentResource() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"iscsi"}, false),
+				ValidateFunc: validation.StringInSlice(volumeAttachmentTypes, false),
 			},
 			"compartment_id": {
 				Type:     schema.TypeString,
@@ -43,7 +56,20 @@ func VolumeAttachmentResource() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			//// Optional ////
+			"is_read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"generate_iscsi_commands": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			//// Computed ////
+			"etag": crud.ETagSchema,
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -67,8 +93,9 @@ func VolumeAttachmentResource() *schema.Resource {
 			},
 			// The following are only computed if type == "iscsi"
 			"chap_secret": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 			"chap_username": {
 				Type:     schema.TypeString,
@@ -87,6 +114,19 @@ func VolumeAttachmentResource() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			// The following are only computed if generate_iscsi_commands is set
+			// The login commands hold the CHAP secret.
+			"iscsi_login_commands": {
+				Type:      schema.TypeList,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+			"iscsi_logout_commands": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -107,6 +147,14 @@ func readVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
 	return crud.ReadResource(sync)
 }
 
+func updateVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(client.BareMetalClient)
+	sync := &VolumeAttachmentResourceCrud{}
+	sync.D = d
+	sync.Client = client
+	return crud.UpdateResource(d, sync)
+}
+
 func deleteVolumeAttachment(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(client.BareMetalClient)
 	sync := &VolumeAttachmentResourceCrud{}
@@ -145,15 +193,41 @@ func (s *VolumeAttachmentResourceCrud) State() string {
 }
 
 func (s *VolumeAttachmentResourceCrud) Create() (e error) {
+	if e = s.checkISCSICommands(); e != nil {
+		return
+	}
+
 	attachmentType := s.D.Get("attachment_type").(string)
 	instanceID := s.D.Get("instance_id").(string)
 	volumeID := s.D.Get("volume_id").(string)
 
-	s.Res, e = s.Client.AttachVolume(attachmentType, instanceID, volumeID, nil)
+	opts := &baremetal.AttachVolumeOptions{}
+	opts.RetryToken = s.RetryToken
+	opts.IsReadOnly = s.D.Get("is_read_only").(bool)
+	s.Res, e = s.Client.AttachVolume(attachmentType, instanceID, volumeID, opts)
 
 	return
 }
 
+// Update only switches the generated iSCSI commands on or off, the
+// attachment itself is left as it is.
+func (s *VolumeAttachmentResourceCrud) Update() (e error) {
+	if e = s.checkISCSICommands(); e != nil {
+		return
+	}
+	return s.Get()
+}
+
+// checkISCSICommands rejects generating iSCSI commands for attachments that
+// aren't iSCSI.
+func (s *VolumeAttachmentResourceCrud) checkISCSICommands() (e error) {
+	attachmentType := s.D.Get("attachment_type").(string)
+	if s.D.Get("generate_iscsi_commands").(bool) && attachmentType != baremetal.VolumeAttachmentISCSI {
+		return fmt.Errorf("iSCSI commands can't be generated for %s volume attachments", attachmentType)
+	}
+	return
+}
+
 func (s *VolumeAttachmentResourceCrud) Get() (e error) {
 	s.Res, e = s.Client.GetVolumeAttachment(s.D.Id())
 	return
@@ -165,16 +239,30 @@ func (s *VolumeAttachmentResourceCrud) SetData() {
 	s.D.Set("compartment_id", s.Res.CompartmentID)
 	s.D.Set("display_name", s.Res.DisplayName)
 	s.D.Set("instance_id", s.Res.InstanceID)
+	s.D.Set("is_read_only", s.Res.IsReadOnly)
 	s.D.Set("state", s.Res.State)
 	s.D.Set("time_created", s.Res.TimeCreated.String())
 	s.D.Set("volume_id", s.Res.VolumeID)
+
+	if s.Res.AttachmentType != baremetal.VolumeAttachmentISCSI {
+		return
+	}
 	s.D.Set("chap_secret", s.Res.CHAPSecret)
 	s.D.Set("chap_username", s.Res.CHAPUsername)
 	s.D.Set("ipv4", s.Res.IPv4)
 	s.D.Set("iqn", s.Res.IQN)
 	s.D.Set("port", s.Res.Port)
+
+	// Keeps state from before generate_iscsi_commands existed from showing a diff.
+	s.D.Set("generate_iscsi_commands", s.D.Get("generate_iscsi_commands"))
+	login, logout := []string{}, []string{}
+	if s.D.Get("generate_iscsi_commands").(bool) {
+		login, logout = iscsiCommands(s.Res)
+	}
+	s.D.Set("iscsi_login_commands", login)
+	s.D.Set("iscsi_logout_commands", logout)
 }
 
 func (s *VolumeAttachmentResourceCrud) Delete() (e error) {
-	return s.Client.DetachVolume(s.D.Id(), nil)
+	return s.Client.DetachVolume(s.D.Id(), s.IfMatchOptions())
 }
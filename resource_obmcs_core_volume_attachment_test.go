@@ -106,6 +106,12 @@ func (s *ResourceCoreVolumeAttachmentTestSuite) TestDetachVolume() {
 
 }
 
+func (s *ResourceCoreVolumeAttachmentTestSuite) TestCHAPSecretIsSensitive() {
+	schema := VolumeAttachmentResource().Schema
+	s.True(schema["chap_secret"].Sensitive)
+	s.True(schema["iscsi_login_commands"].Sensitive)
+}
+
 func TestResourceCoreVolumeAttachmentTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceCoreVolumeAttachmentTestSuite))
 }
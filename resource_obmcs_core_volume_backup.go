@@ -21,6 +21,7 @@ func VolumeBackupResource() *schema.Resource {
 		Update:   updateVolumeBackup,
 		Delete:   deleteVolumeBackup,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"compartment_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -134,6 +135,7 @@ func (s *VolumeBackupResourceCrud) Create() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.CreateVolumeBackup(volumeID, opts)
 
 	return
@@ -151,6 +153,7 @@ func (s *VolumeBackupResourceCrud) Update() (e error) {
 		opts.DisplayName = displayName.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateVolumeBackup(s.D.Id(), opts)
 
 	return
@@ -170,5 +173,5 @@ func (s *VolumeBackupResourceCrud) SetData() {
 }
 
 func (s *VolumeBackupResourceCrud) Delete() (e error) {
-	return s.Client.DeleteVolumeBackup(s.D.Id(), nil)
+	return s.Client.DeleteVolumeBackup(s.D.Id(), s.IfMatchOptions())
 }
@@ -0,0 +1,195 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// VolumeBackupPolicyResource keeps a rolling set of backups of a volume: a new
+// backup every schedule, and no more than retention_count of them. Terraform
+// only acts when applied, so the policy is kept by applying regularly. A
+// refresh marks the policy out of date when a backup is due or backups are
+// past retention, and the next apply takes and prunes backups.
+func VolumeBackupPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+			Update: &crud.TwoHours,
+		},
+		Create: createVolumeBackupPolicy,
+		Read:   readVolumeBackupPolicy,
+		Update: updateVolumeBackupPolicy,
+		Delete: deleteVolumeBackupPolicy,
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schedule": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSchedule,
+			},
+			"retention_count": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+			},
+			"display_name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "policy-backup",
+			},
+			"up_to_date": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"backup_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"next_backup_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func updateVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteVolumeBackupPolicy(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type VolumeBackupPolicyResourceCrud struct {
+	crud.BaseCrud
+	Volume  *baremetal.Volume
+	Backups []baremetal.VolumeBackup
+	// backupName is the name of the backup being taken. It's worked out on
+	// the first attempt, so that retried attempts send the same create with
+	// the same retry token.
+	backupName string
+}
+
+func (s *VolumeBackupPolicyResourceCrud) ID() string {
+	return fmt.Sprintf("%s/%s", s.D.Get("volume_id").(string), s.D.Get("display_name_prefix").(string))
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Create() (e error) {
+	return s.reconcile(s.D.Timeout(schema.TimeoutCreate))
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Get() (e error) {
+	if s.Volume, e = s.Client.GetVolume(s.D.Get("volume_id").(string)); e != nil {
+		return
+	}
+	s.Backups, e = policyBackups(s.Client, s.Volume.CompartmentID, s.Volume.ID, s.D.Get("display_name_prefix").(string))
+	return
+}
+
+func (s *VolumeBackupPolicyResourceCrud) Update() (e error) {
+	return s.reconcile(s.D.Timeout(schema.TimeoutUpdate))
+}
+
+// reconcile takes a backup if one is due, waits for it, then deletes the
+// backups past retention.
+func (s *VolumeBackupPolicyResourceCrud) reconcile(timeout time.Duration) (e error) {
+	if e = s.Get(); e != nil {
+		return
+	}
+
+	schedule, _ := time.ParseDuration(s.D.Get("schedule").(string))
+	now := time.Now()
+	if !now.Before(nextBackupTime(s.Backups, schedule, now)) {
+		if s.backupName == "" {
+			s.backupName = fmt.Sprintf("%s-%s", s.D.Get("display_name_prefix").(string), now.UTC().Format("20060102150405"))
+		}
+		opts := &baremetal.CreateOptions{}
+		opts.DisplayName = s.backupName
+		opts.RetryToken = s.RetryToken
+
+		backup := &VolumeBackupResourceCrud{}
+		backup.D = VolumeBackupResource().Data(nil)
+		backup.Client = s.Client
+		if backup.Res, e = s.Client.CreateVolumeBackup(s.Volume.ID, opts); e != nil {
+			return
+		}
+		backup.D.SetId(backup.Res.ID)
+		if e = crud.WaitForState(backup, timeout, backup.CreatedPending(), backup.CreatedTarget()); e != nil {
+			return
+		}
+		s.Backups = append([]baremetal.VolumeBackup{*backup.Res}, s.Backups...)
+	}
+
+	retained, expired := expireBackups(s.Backups, s.D.Get("retention_count").(int))
+	for _, b := range expired {
+		if e = s.Client.DeleteVolumeBackup(b.ID, nil); e != nil {
+			return
+		}
+	}
+	s.Backups = retained
+	return
+}
+
+func (s *VolumeBackupPolicyResourceCrud) SetData() {
+	s.D.Set("compartment_id", s.Volume.CompartmentID)
+
+	retained, expired := expireBackups(s.Backups, s.D.Get("retention_count").(int))
+	ids := []string{}
+	for _, b := range retained {
+		ids = append(ids, b.ID)
+	}
+	s.D.Set("backup_ids", ids)
+
+	schedule, _ := time.ParseDuration(s.D.Get("schedule").(string))
+	now := time.Now()
+	next := nextBackupTime(s.Backups, schedule, now)
+	s.D.Set("next_backup_time", next.UTC().Format(time.RFC3339))
+	s.D.Set("up_to_date", len(expired) == 0 && now.Before(next))
+}
+
+// Delete only forgets the policy, the backups it took are kept.
+func (s *VolumeBackupPolicyResourceCrud) Delete() (e error) {
+	return
+}
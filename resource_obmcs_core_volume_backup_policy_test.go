@@ -0,0 +1,211 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+type ResourceCoreVolumeBackupPolicyTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceCoreVolumeBackupPolicyTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+
+	s.Provider = Provider(
+		func(d *schema.ResourceData) (interface{}, error) {
+			return s.Client, nil
+		},
+	)
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+
+	s.Config = `
+	data "baremetal_identity_availability_domains" "ADs" {
+	  compartment_id = "${var.compartment_id}"
+	}
+
+	resource "baremetal_core_volume" "t" {
+	  availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.0.name}"
+	  compartment_id = "${var.compartment_id}"
+	  size_in_mbs = 262144
+	}
+	` + testProviderConfig()
+
+	s.ResourceName = "baremetal_core_volume_backup_policy.t"
+}
+
+func (s *ResourceCoreVolumeBackupPolicyTestSuite) TestRotateBackups() {
+	config := s.Config + `
+	resource "baremetal_core_volume_backup_policy" "t" {
+	  volume_id = "${baremetal_core_volume.t.id}"
+	  schedule = "3s"
+	  retention_count = 1
+	}
+	`
+
+	var first string
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					func(ts *terraform.State) error {
+						first = ts.RootModule().Resources[s.ResourceName].Primary.Attributes["backup_ids.0"]
+						return nil
+					},
+					resource.TestCheckResourceAttr(s.ResourceName, "backup_ids.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "up_to_date", "true"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "next_backup_time"),
+					resource.TestCheckResourceAttrPair(s.ResourceName, "compartment_id", "baremetal_core_volume.t", "compartment_id"),
+				),
+			},
+			// Once the schedule has passed, the next apply takes a new backup
+			// and deletes the old one.
+			{
+				PreConfig: func() { time.Sleep(4 * time.Second) },
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "backup_ids.#", "1"),
+					func(ts *terraform.State) error {
+						if id := ts.RootModule().Resources[s.ResourceName].Primary.Attributes["backup_ids.0"]; id == first {
+							return fmt.Errorf("Backup %s was not rotated", first)
+						}
+						backup, err := s.Client.GetVolumeBackup(first)
+						if err != nil {
+							return err
+						}
+						if backup.State != baremetal.ResourceTerminating && backup.State != baremetal.ResourceTerminated {
+							return fmt.Errorf("Expired backup %s is %s", first, backup.State)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreVolumeBackupPolicyTestSuite) TestReadExpiredBackups() {
+	config := s.Config + `
+	resource "baremetal_core_volume_backup" "a" {
+	  volume_id = "${baremetal_core_volume.t.id}"
+	  display_name = "nightly-a"
+	}
+
+	resource "baremetal_core_volume_backup" "b" {
+	  volume_id = "${baremetal_core_volume.t.id}"
+	  display_name = "nightly-b"
+	  depends_on = ["baremetal_core_volume_backup.a"]
+	}
+
+	resource "baremetal_core_volume_backup" "manual" {
+	  volume_id = "${baremetal_core_volume.t.id}"
+	  display_name = "manual"
+	  depends_on = ["baremetal_core_volume_backup.b"]
+	}
+
+	data "baremetal_core_volume_backup_policy" "t" {
+	  compartment_id = "${var.compartment_id}"
+	  volume_id = "${baremetal_core_volume_backup.manual.volume_id}"
+	  display_name_prefix = "nightly-"
+	  schedule = "24h"
+	  retention_count = 1
+	}
+
+	data "baremetal_core_volume_backup_policy" "filtered" {
+	  compartment_id = "${var.compartment_id}"
+	  volume_id = "${baremetal_core_volume_backup.manual.volume_id}"
+	  display_name_prefix = "nightly-"
+	  schedule = "24h"
+	  retention_count = 1
+	  filter {
+	    name = "display_name"
+	    values = ["nightly-a"]
+	  }
+	}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.t", "retained_backups.#", "1"),
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.t", "expired_backups.#", "1"),
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.t", "backup_due", "false"),
+					resource.TestCheckResourceAttrSet("data.baremetal_core_volume_backup_policy.t", "next_backup_time"),
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.filtered", "retained_backups.#", "1"),
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.filtered", "retained_backups.0.display_name", "nightly-a"),
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backup_policy.filtered", "expired_backups.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// A retried backup create sends the same name with the same retry token,
+// even when the attempts are made at different times.
+func TestRetriedPolicyBackupKeepsItsName(t *testing.T) {
+	client := &mocks.BareMetalClient{}
+	client.On("GetVolume", "volume").Return(&baremetal.Volume{ID: "volume", CompartmentID: "compartment"}, nil)
+	client.On("ListVolumeBackups", "compartment", mock.Anything).Return(&baremetal.ListVolumeBackups{}, nil)
+	client.On("GetVolumeBackup", "backup").Return(&baremetal.VolumeBackup{ID: "backup", State: baremetal.ResourceAvailable}, nil)
+
+	var sent []baremetal.CreateOptions
+	record := func(args mock.Arguments) {
+		sent = append(sent, *args.Get(1).(*baremetal.CreateOptions))
+	}
+	client.On("CreateVolumeBackup", "volume", mock.Anything).Run(record).After(time.Second).
+		Return(nil, &baremetal.Error{Status: "500", Code: "InternalServerError"}).Once()
+	client.On("CreateVolumeBackup", "volume", mock.Anything).Run(record).
+		Return(&baremetal.VolumeBackup{ID: "backup", State: baremetal.ResourceAvailable}, nil)
+
+	d := VolumeBackupPolicyResource().Data(nil)
+	d.Set("volume_id", "volume")
+	d.Set("schedule", "24h")
+	d.Set("retention_count", 2)
+	d.Set("display_name_prefix", "nightly")
+	sync := &VolumeBackupPolicyResourceCrud{}
+	sync.D = d
+	sync.Client = client
+	sync.RetryToken = "token"
+
+	policy := &crud.RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{500}}
+	if e := policy.Do(func() error { return sync.reconcile(time.Minute) }); e != nil {
+		t.Fatal(e)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("Expected two attempts at the backup, got %d", len(sent))
+	}
+	if sent[0] != sent[1] {
+		t.Errorf("Expected the retried create to be the same, got %+v then %+v", sent[0], sent[1])
+	}
+}
+
+func TestResourceCoreVolumeBackupPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceCoreVolumeBackupPolicyTestSuite))
+}
@@ -3,6 +3,9 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -147,6 +150,104 @@ func (s ResourceCoreVolumeTestSuite) TestUpdateAvailabilityDomainForcesNewVolume
 	})
 }
 
+func (s *ResourceCoreVolumeTestSuite) TestResizeVolumeInPlace() {
+	grown := strings.Replace(s.Config, "size_in_mbs = 262144", "size_in_mbs = 524288", 1)
+	shrunk := strings.Replace(s.Config, "size_in_mbs = 262144", "size_in_mbs = 131072", 1)
+
+	var id string
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+				Check: func(ts *terraform.State) error {
+					id = ts.RootModule().Resources[s.ResourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				Config: grown,
+				Check: resource.ComposeTestCheckFunc(
+					func(ts *terraform.State) error {
+						if newID := ts.RootModule().Resources[s.ResourceName].Primary.ID; newID != id {
+							return fmt.Errorf("Volume was recreated, %s became %s", id, newID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(s.ResourceName, "size_in_mbs", "524288"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			{
+				Config:      shrunk,
+				ExpectError: regexp.MustCompile("can't shrink from 524288 to 131072 MBs"),
+			},
+		},
+	})
+}
+
+func (s *ResourceCoreVolumeTestSuite) TestRestoreAndCloneVolume() {
+	config := s.Config + `
+		resource "baremetal_core_volume_backup" "t" {
+			volume_id = "${baremetal_core_volume.t.id}"
+			display_name = "nightly"
+		}
+		data "baremetal_core_volume_backups" "t" {
+			compartment_id = "${var.compartment_id}"
+			volume_id = "${baremetal_core_volume_backup.t.volume_id}"
+			display_name = "nightly"
+			most_recent = true
+		}
+		resource "baremetal_core_volume" "restored" {
+			availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.1.name}"
+			compartment_id = "${var.compartment_id}"
+			source_details {
+				type = "volumeBackup"
+				id = "${data.baremetal_core_volume_backups.t.volume_backups.0.id}"
+			}
+		}
+		resource "baremetal_core_volume" "cloned" {
+			availability_domain = "${baremetal_core_volume.t.availability_domain}"
+			compartment_id = "${var.compartment_id}"
+			source_details {
+				type = "volume"
+				id = "${baremetal_core_volume.t.id}"
+			}
+		}
+	`
+	crossAD := s.Config + `
+		resource "baremetal_core_volume" "cloned" {
+			availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.2.name}"
+			compartment_id = "${var.compartment_id}"
+			source_details {
+				type = "volume"
+				id = "${baremetal_core_volume.t.id}"
+			}
+		}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.baremetal_core_volume_backups.t", "volume_backups.#", "1"),
+					resource.TestCheckResourceAttrPair("baremetal_core_volume.restored", "source_details.0.id", "baremetal_core_volume_backup.t", "id"),
+					resource.TestCheckResourceAttr("baremetal_core_volume.restored", "size_in_mbs", "262144"),
+					resource.TestCheckResourceAttr("baremetal_core_volume.restored", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrPair("baremetal_core_volume.cloned", "source_details.0.id", s.ResourceName, "id"),
+					resource.TestCheckResourceAttr("baremetal_core_volume.cloned", "state", baremetal.ResourceAvailable),
+				),
+			},
+			{
+				Config:      crossAD,
+				ExpectError: regexp.MustCompile("can't be cloned into .*PHX-AD-3, restore it from a volume backup instead"),
+			},
+		},
+	})
+}
+
 func (s *ResourceCoreVolumeTestSuite) TestDeleteVolume() {
 
 	resource.UnitTest(s.T(), resource.TestCase{
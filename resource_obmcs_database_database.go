@@ -0,0 +1,153 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// DatabaseResource adds a database to an existing DB home.
+func DatabaseResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+			Delete: &crud.TwoHours,
+		},
+		Create: createDatabaseResource,
+		Read:   readDatabaseResource,
+		Delete: deleteDatabaseResource,
+		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
+			"db_home_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"admin_password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"db_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"character_set": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ncharacter_set": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"compartment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"db_unique_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDatabaseResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DatabaseResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readDatabaseResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DatabaseResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func deleteDatabaseResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DatabaseResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type DatabaseResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.Database
+}
+
+func (s *DatabaseResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *DatabaseResourceCrud) CreatedPending() []string {
+	return []string{baremetal.ResourceProvisioning}
+}
+
+func (s *DatabaseResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *DatabaseResourceCrud) DeletedPending() []string {
+	return []string{baremetal.ResourceTerminating}
+}
+
+func (s *DatabaseResourceCrud) DeletedTarget() []string {
+	return []string{baremetal.ResourceTerminated}
+}
+
+func (s *DatabaseResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *DatabaseResourceCrud) Create() (e error) {
+	database := baremetal.NewCreateDatabaseDetails(
+		s.D.Get("admin_password").(string),
+		s.D.Get("db_name").(string),
+		s.D.Get("character_set").(string),
+		s.D.Get("ncharacter_set").(string),
+	)
+	opts := &baremetal.RetryTokenOptions{RetryToken: s.RetryToken}
+	s.Res, e = s.Client.CreateDatabase(s.D.Get("db_home_id").(string), database, opts)
+	return
+}
+
+func (s *DatabaseResourceCrud) Get() (e error) {
+	s.Res, e = s.Client.GetDatabase(s.D.Id())
+	return
+}
+
+func (s *DatabaseResourceCrud) SetData() {
+	s.D.Set("compartment_id", s.Res.CompartmentID)
+	s.D.Set("db_home_id", s.Res.DBHomeID)
+	s.D.Set("db_name", s.Res.DBName)
+	s.D.Set("db_unique_name", s.Res.DBUniqueName)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *DatabaseResourceCrud) Delete() (e error) {
+	return s.Client.DeleteDatabase(s.D.Id(), s.IfMatchOptions())
+}
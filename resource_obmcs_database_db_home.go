@@ -0,0 +1,141 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// DBHomeResource adds a DB home, with a first database, to an existing DB
+// system.
+func DBHomeResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+			Delete: &crud.TwoHours,
+		},
+		Create: createDBHomeResource,
+		Read:   readDBHomeResource,
+		Delete: deleteDBHomeResource,
+		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
+			"db_system_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"db_version": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"database": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem:     databaseDetailsResource,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDBHomeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBHomeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readDBHomeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBHomeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func deleteDBHomeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBHomeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type DBHomeResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.DBHome
+}
+
+func (s *DBHomeResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+func (s *DBHomeResourceCrud) CreatedPending() []string {
+	return []string{baremetal.ResourceProvisioning}
+}
+
+func (s *DBHomeResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *DBHomeResourceCrud) DeletedPending() []string {
+	return []string{baremetal.ResourceTerminating}
+}
+
+func (s *DBHomeResourceCrud) DeletedTarget() []string {
+	return []string{baremetal.ResourceTerminated}
+}
+
+func (s *DBHomeResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *DBHomeResourceCrud) Create() (e error) {
+	dbHome := baremetal.CreateDBHomeDetails{
+		Database:    databaseDetails(s.D.Get("database").([]interface{})),
+		DBVersion:   s.D.Get("db_version").(string),
+		DisplayName: s.D.Get("display_name").(string),
+	}
+	opts := &baremetal.RetryTokenOptions{RetryToken: s.RetryToken}
+	s.Res, e = s.Client.CreateDBHome(s.D.Get("db_system_id").(string), dbHome, opts)
+	return
+}
+
+func (s *DBHomeResourceCrud) Get() (e error) {
+	s.Res, e = s.Client.GetDBHome(s.D.Id())
+	return
+}
+
+func (s *DBHomeResourceCrud) SetData() {
+	s.D.Set("db_system_id", s.Res.DBSystemID)
+	s.D.Set("db_version", s.Res.DBVersion)
+	s.D.Set("display_name", s.Res.DisplayName)
+	s.D.Set("state", s.Res.State)
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+}
+
+func (s *DBHomeResourceCrud) Delete() (e error) {
+	return s.Client.DeleteDBHome(s.D.Id(), s.IfMatchOptions())
+}
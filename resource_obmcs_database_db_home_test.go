@@ -0,0 +1,93 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResourceDatabaseDBHomeTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	Config       string
+	ResourceName string
+}
+
+func (s *ResourceDatabaseDBHomeTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+
+	s.Provider = Provider(
+		func(d *schema.ResourceData) (interface{}, error) {
+			return s.Client, nil
+		},
+	)
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+
+	s.Config = databaseConfig + `
+	resource "baremetal_database_db_home" "t" {
+	  db_system_id = "${baremetal_database_db_system.t.id}"
+	  db_version = "${var.DBVersion}"
+	  display_name = "MyTFDBHome2"
+	  database {
+	    admin_password = "${var.DBAdminPassword}"
+	    db_name = "aTFdb2"
+	  }
+	}
+
+	resource "baremetal_database_database" "t" {
+	  db_home_id = "${baremetal_database_db_home.t.id}"
+	  admin_password = "${var.DBAdminPassword}"
+	  db_name = "aTFdb3"
+	  character_set = "AL32UTF8"
+	}
+
+	data "baremetal_database_db_home" "t" {
+	  db_home_id = "${baremetal_database_db_home.t.id}"
+	}
+
+	data "baremetal_database_database" "t" {
+	  database_id = "${baremetal_database_database.t.id}"
+	}
+	` + testProviderConfig()
+
+	s.ResourceName = "baremetal_database_db_home.t"
+}
+
+func (s *ResourceDatabaseDBHomeTestSuite) TestCreateResourceDatabaseDBHome() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "db_system_id", "baremetal_database_db_system.t", "id"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "MyTFDBHome2"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("baremetal_database_database.t", "db_name", "aTFdb3"),
+					resource.TestCheckResourceAttrSet("baremetal_database_database.t", "db_unique_name"),
+					resource.TestCheckResourceAttr("baremetal_database_database.t", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("data.baremetal_database_db_home.t", "db_version", "12.1.0.2"),
+					resource.TestCheckResourceAttr("data.baremetal_database_db_home.t", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttrPair("data.baremetal_database_database.t", "db_home_id", s.ResourceName, "id"),
+					resource.TestCheckResourceAttr("data.baremetal_database_database.t", "state", baremetal.ResourceAvailable),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceDatabaseDBHomeTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceDatabaseDBHomeTestSuite))
+}
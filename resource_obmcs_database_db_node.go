@@ -0,0 +1,200 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// DBNodeResource manages the power state of a node of a DB system. Nodes are
+// launched and terminated with their DB system, so creating the resource only
+// takes charge of the node and destroying it leaves the node as it is.
+func DBNodeResource() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+			Update: &crud.TwoHours,
+		},
+		Create: createDBNodeResource,
+		Read:   readDBNodeResource,
+		Update: updateDBNodeResource,
+		Delete: deleteDBNodeResource,
+		Schema: map[string]*schema.Schema{
+			"db_node_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"desired_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					baremetal.ResourceAvailable,
+					baremetal.ResourceStopped,
+				}, false),
+			},
+			"db_system_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"time_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vnic_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDBNodeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+
+	desiredState := d.Get("desired_state").(string)
+	if e = crud.CreateResource(d, sync); e != nil {
+		return
+	}
+	if desiredState != "" && desiredState != sync.Res.State {
+		if e = sync.setPowerState(desiredState, d.Timeout(schema.TimeoutCreate)); e != nil {
+			return
+		}
+		sync.SetData()
+	}
+	return
+}
+
+func readDBNodeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func updateDBNodeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.UpdateResource(d, sync)
+}
+
+func deleteDBNodeResource(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type DBNodeResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.DBNode
+}
+
+func (s *DBNodeResourceCrud) ID() string {
+	return s.Res.ID
+}
+
+// CreatedPending lets a node that is starting or stopping settle before its
+// desired state is applied.
+func (s *DBNodeResourceCrud) CreatedPending() []string {
+	return []string{
+		baremetal.ResourceProvisioning,
+		baremetal.ResourceStarting,
+		baremetal.ResourceStopping,
+	}
+}
+
+func (s *DBNodeResourceCrud) CreatedTarget() []string {
+	return []string{
+		baremetal.ResourceAvailable,
+		baremetal.ResourceStopped,
+	}
+}
+
+func (s *DBNodeResourceCrud) State() string {
+	return s.Res.State
+}
+
+func (s *DBNodeResourceCrud) Create() (e error) {
+	s.Res, e = s.Client.GetDBNode(s.D.Get("db_node_id").(string))
+	return
+}
+
+func (s *DBNodeResourceCrud) Get() (e error) {
+	s.Res, e = s.Client.GetDBNode(s.D.Id())
+	return
+}
+
+func (s *DBNodeResourceCrud) Update() (e error) {
+	if s.D.HasChange("desired_state") {
+		return s.setPowerState(s.D.Get("desired_state").(string), s.D.Timeout(schema.TimeoutUpdate))
+	}
+	return s.Get()
+}
+
+// setPowerState starts or stops the node and waits until it is AVAILABLE or
+// STOPPED.
+func (s *DBNodeResourceCrud) setPowerState(state string, timeout time.Duration) (e error) {
+	var action baremetal.DBNodeAction
+	var pending []string
+	switch state {
+	case baremetal.ResourceAvailable:
+		action = baremetal.DBNodeActionStart
+		pending = []string{baremetal.ResourceStopped, baremetal.ResourceStarting}
+	case baremetal.ResourceStopped:
+		action = baremetal.DBNodeActionStop
+		pending = []string{baremetal.ResourceAvailable, baremetal.ResourceStopping}
+	default:
+		return fmt.Errorf("DB node %s can't be put in state %s", s.D.Id(), state)
+	}
+
+	if s.Res, e = s.Client.DBNodeAction(s.D.Id(), action, nil); e != nil {
+		return
+	}
+	return crud.WaitForState(s, timeout, pending, []string{state})
+}
+
+func (s *DBNodeResourceCrud) SetData() {
+	s.D.Set("db_node_id", s.Res.ID)
+	s.D.Set("db_system_id", s.Res.DBSystemID)
+	s.D.Set("hostname", s.Res.Hostname)
+	s.D.Set("state", s.Res.State)
+	// Only settled power states are desired states.
+	if s.Res.State == baremetal.ResourceAvailable || s.Res.State == baremetal.ResourceStopped {
+		s.D.Set("desired_state", s.Res.State)
+	}
+	s.D.Set("time_created", s.Res.TimeCreated.String())
+	s.D.Set("vnic_id", s.Res.VnicID)
+}
+
+// Delete only forgets the node, it is terminated with its DB system.
+func (s *DBNodeResourceCrud) Delete() (e error) {
+	return
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/client"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+// DBNodeActionResource resets a DB node once when it is created. Changing the
+// node, action or triggers resets it again.
+func DBNodeActionResource() *schema.Resource {
+	return &schema.Resource{
+		Timeouts: &schema.ResourceTimeout{
+			Create: &crud.TwoHours,
+		},
+		Create: createDBNodeAction,
+		Read:   readDBNodeAction,
+		Delete: deleteDBNodeAction,
+		Schema: map[string]*schema.Schema{
+			"db_node_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(baremetal.DBNodeActionSoftReset),
+					string(baremetal.DBNodeActionReset),
+				}, false),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func createDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.CreateResource(d, sync)
+}
+
+func readDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.ReadResource(sync)
+}
+
+func deleteDBNodeAction(d *schema.ResourceData, m interface{}) (e error) {
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = m.(client.BareMetalClient)
+	return crud.DeleteResource(d, sync)
+}
+
+type DBNodeActionResourceCrud struct {
+	crud.BaseCrud
+	Res *baremetal.DBNode
+}
+
+func (s *DBNodeActionResourceCrud) ID() string {
+	return s.Res.ID + "/" + s.D.Get("action").(string)
+}
+
+func (s *DBNodeActionResourceCrud) CreatedPending() []string {
+	return []string{
+		baremetal.ResourceStopping,
+		baremetal.ResourceStopped,
+		baremetal.ResourceStarting,
+	}
+}
+
+func (s *DBNodeActionResourceCrud) CreatedTarget() []string {
+	return []string{baremetal.ResourceAvailable}
+}
+
+func (s *DBNodeActionResourceCrud) Create() (e error) {
+	action := baremetal.DBNodeAction(s.D.Get("action").(string))
+	s.Res, e = s.Client.DBNodeAction(s.D.Get("db_node_id").(string), action, nil)
+	return
+}
+
+func (s *DBNodeActionResourceCrud) Get() (e error) {
+	if s.Res, e = s.Client.GetDBNode(s.D.Get("db_node_id").(string)); e != nil {
+		return
+	}
+	if s.Res.State == baremetal.ResourceTerminated {
+		return crud.NewNotFoundError("DB node %s is terminated", s.Res.ID)
+	}
+	return
+}
+
+func (s *DBNodeActionResourceCrud) SetData() {
+	s.D.Set("db_node_id", s.Res.ID)
+	s.D.Set("state", s.Res.State)
+}
+
+// Delete only forgets the action, the node is left as it is.
+func (s *DBNodeActionResourceCrud) Delete() (e error) {
+	return
+}
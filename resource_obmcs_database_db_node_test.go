@@ -0,0 +1,120 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/MustWin/baremetal-sdk-go"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+type ResourceDatabaseDBNodeTestSuite struct {
+	suite.Suite
+	Client       mockableClient
+	Provider     terraform.ResourceProvider
+	Providers    map[string]terraform.ResourceProvider
+	ResourceName string
+}
+
+func (s *ResourceDatabaseDBNodeTestSuite) SetupTest() {
+	s.Client = GetTestProvider()
+
+	s.Provider = Provider(
+		func(d *schema.ResourceData) (interface{}, error) {
+			return s.Client, nil
+		},
+	)
+
+	s.Providers = map[string]terraform.ResourceProvider{
+		"baremetal": s.Provider,
+	}
+
+	s.ResourceName = "baremetal_database_db_node.t"
+}
+
+func dbNodeConfig(desiredState, extra string) string {
+	return databaseConfig + `
+	data "baremetal_database_db_nodes" "t" {
+	  compartment_id = "${var.compartment_id}"
+	  db_system_id = "${baremetal_database_db_system.t.id}"
+	}
+
+	resource "baremetal_database_db_node" "t" {
+	  db_node_id = "${data.baremetal_database_db_nodes.t.db_nodes.0.id}"
+	  desired_state = "` + desiredState + `"
+	}
+	` + extra + testProviderConfig()
+}
+
+func (s *ResourceDatabaseDBNodeTestSuite) TestPowerActionsResourceDatabaseDBNode() {
+	reset := `
+	resource "baremetal_database_db_node_action" "t" {
+	  db_node_id = "${baremetal_database_db_node.t.id}"
+	  action = "SOFTRESET"
+	}
+	`
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: dbNodeConfig(baremetal.ResourceStopped, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(s.ResourceName, "db_system_id", "baremetal_database_db_system.t", "id"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "hostname"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceStopped),
+				),
+			},
+			{
+				Config: dbNodeConfig(baremetal.ResourceAvailable, ""),
+				Check:  resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+			},
+			{
+				Config: dbNodeConfig(baremetal.ResourceAvailable, reset),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("baremetal_database_db_node_action.t", "state", baremetal.ResourceAvailable),
+					resource.TestCheckResourceAttr("baremetal_database_db_node_action.t", "action", "SOFTRESET"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+			{
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDBNodeActionTerminatedDBNode(t *testing.T) {
+	client := &mocks.BareMetalClient{}
+	node := &baremetal.DBNode{ID: "node", State: baremetal.ResourceTerminated}
+	client.On("GetDBNode", "node").Return(node, nil)
+
+	d := DBNodeActionResource().Data(nil)
+	d.SetId("node/SOFTRESET")
+	d.Set("db_node_id", "node")
+	d.Set("action", "SOFTRESET")
+	sync := &DBNodeActionResourceCrud{}
+	sync.D = d
+	sync.Client = client
+
+	if e := crud.ReadResource(sync); e != nil {
+		t.Fatal(e)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected the action on a terminated DB node to be gone, got ID %q", d.Id())
+	}
+}
+
+func TestResourceDatabaseDBNodeTestSuite(t *testing.T) {
+	suite.Run(t, new(ResourceDatabaseDBNodeTestSuite))
+}
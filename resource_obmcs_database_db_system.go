@@ -3,10 +3,15 @@
 package main
 
 import (
+	"fmt"
+	"log"
+	"strings"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
+	"github.com/oracle/terraform-provider-baremetal/options"
 )
 
 func DBSystemResource() *schema.Resource {
@@ -21,8 +26,10 @@ func DBSystemResource() *schema.Resource {
 		},
 		Create: createDBSystem,
 		Read:   readDBSystem,
+		Update: updateDBSystem,
 		Delete: deleteDBSystem,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"availability_domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -46,7 +53,6 @@ func DBSystemResource() *schema.Resource {
 			"ssh_public_keys": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -54,13 +60,11 @@ func DBSystemResource() *schema.Resource {
 			"cpu_core_count": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"display_name": {
 				Type:     schema.TypeString,
 				Computed: true,
-				ForceNew: true,
 				Optional: true,
 			},
 			"database_edition": {
@@ -81,27 +85,7 @@ func DBSystemResource() *schema.Resource {
 							Type:     schema.TypeList,
 							Required: true,
 							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"admin_password": {
-										Type:      schema.TypeString,
-										Required:  true,
-										Sensitive: true,
-									},
-									"db_name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"character_set": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"ncharacter_set": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-								},
-							},
+							Elem:     databaseDetailsResource,
 						},
 						"db_version": {
 							Type:     schema.TypeString,
@@ -163,6 +147,9 @@ func createDBSystem(d *schema.ResourceData, m interface{}) (e error) {
 	sync := &DBSystemResourceCrud{}
 	sync.D = d
 	sync.Client = client
+	if e = sync.validateLaunch(); e != nil {
+		return
+	}
 	return crud.CreateResource(d, sync)
 }
 
@@ -174,6 +161,14 @@ func readDBSystem(d *schema.ResourceData, m interface{}) (e error) {
 	return crud.ReadResource(sync)
 }
 
+func updateDBSystem(d *schema.ResourceData, m interface{}) (e error) {
+	client := m.(client.BareMetalClient)
+	sync := &DBSystemResourceCrud{}
+	sync.D = d
+	sync.Client = client
+	return crud.UpdateResource(d, sync)
+}
+
 func deleteDBSystem(d *schema.ResourceData, m interface{}) (e error) {
 	client := m.(client.BareMetalClient)
 	sync := &DBSystemResourceCrud{}
@@ -195,8 +190,24 @@ func (s *DBSystemResourceCrud) CreatedPending() []string {
 	return []string{baremetal.ResourceProvisioning}
 }
 
+// CreatedTarget includes FAILED so a launch the service rejects stops the wait
+// and is reported with its lifecycle details.
 func (s *DBSystemResourceCrud) CreatedTarget() []string {
-	return []string{baremetal.ResourceAvailable}
+	return []string{
+		baremetal.ResourceAvailable,
+		baremetal.ResourceFailed,
+	}
+}
+
+func (s *DBSystemResourceCrud) UpdatedPending() []string {
+	return []string{baremetal.ResourceUpdating}
+}
+
+func (s *DBSystemResourceCrud) UpdatedTarget() []string {
+	return []string{
+		baremetal.ResourceAvailable,
+		baremetal.ResourceFailed,
+	}
 }
 
 func (s *DBSystemResourceCrud) DeletedPending() []string {
@@ -211,6 +222,111 @@ func (s *DBSystemResourceCrud) State() string {
 	return s.Res.State
 }
 
+// dbSystemShapeEditions are the database editions of the DB system shapes
+// that only support some of them. The service doesn't list the editions of a
+// shape, so these come from its documentation: RAC and Exadata DB systems
+// need Enterprise Edition Extreme Performance. Shapes that aren't here aren't
+// checked.
+var dbSystemShapeEditions = map[string][]baremetal.DatabaseEdition{
+	"BM.RACLocalStorage1.72": {baremetal.DatabaseEditionExtreme},
+	"Exadata.Quarter1.84":    {baremetal.DatabaseEditionExtreme},
+	"Exadata.Half1.168":      {baremetal.DatabaseEditionExtreme},
+	"Exadata.Full1.336":      {baremetal.DatabaseEditionExtreme},
+}
+
+// validateLaunch checks the shape, core count, database version and edition
+// against what the service offers, so a bad combination fails before the
+// launch rather than after a long wait for a FAILED DB system.
+func (s *DBSystemResourceCrud) validateLaunch() (e error) {
+	// The API reference doesn't give the IDs of supported operations, so a
+	// list without LaunchDbSystem is only logged and the service decides.
+	var ops *baremetal.ListSupportedOperations
+	if ops, e = s.Client.ListSupportedOperations(); e != nil {
+		return
+	}
+	supported := false
+	for _, op := range ops.SupportedOperations {
+		supported = supported || op.ID == "LaunchDbSystem"
+	}
+	if !supported {
+		log.Printf("[WARN] LaunchDbSystem isn't among the supported operations, launching anyway")
+	}
+
+	availabilityDomain := s.D.Get("availability_domain").(string)
+	compartmentID := s.D.Get("compartment_id").(string)
+	shapeName := s.D.Get("shape").(string)
+	problems := []string{}
+
+	shapes := []baremetal.DBSystemShape{}
+	shapeOpts := &baremetal.ListOptions{}
+	for {
+		var list *baremetal.ListDBSystemShapes
+		if list, e = s.Client.ListDBSystemShapes(availabilityDomain, compartmentID, shapeOpts); e != nil {
+			return
+		}
+		shapes = append(shapes, list.DBSystemShapes...)
+		if hasNextPage := options.SetNextPageOption(list.NextPage, &shapeOpts.PageListOptions); !hasNextPage {
+			break
+		}
+	}
+	var shape *baremetal.DBSystemShape
+	names := []string{}
+	for i := range shapes {
+		names = append(names, shapes[i].Name)
+		if shapes[i].Name == shapeName {
+			shape = &shapes[i]
+		}
+	}
+	if shape == nil {
+		problems = append(problems, fmt.Sprintf("shape %q is not available in %s, available shapes are %s", shapeName, availabilityDomain, strings.Join(names, ", ")))
+	} else if cores := s.D.Get("cpu_core_count").(int); cores < 1 || uint64(cores) > shape.AvailableCoreCount {
+		problems = append(problems, fmt.Sprintf("cpu_core_count %d is out of range for shape %s, which has %d cores available", cores, shapeName, shape.AvailableCoreCount))
+	}
+
+	edition := baremetal.DatabaseEdition(s.D.Get("database_edition").(string))
+	if editions, ok := dbSystemShapeEditions[shapeName]; ok && edition != "" {
+		found := false
+		needed := []string{}
+		for _, supported := range editions {
+			found = found || supported == edition
+			needed = append(needed, string(supported))
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("database_edition %s is not supported by shape %s, it needs %s", edition, shapeName, strings.Join(needed, " or ")))
+		}
+	}
+
+	if rawDBHome, ok := s.D.GetOk("db_home"); ok && len(rawDBHome.([]interface{})) > 0 {
+		dbVersion := rawDBHome.([]interface{})[0].(map[string]interface{})["db_version"].(string)
+		versions := []string{}
+		versionOpts := &baremetal.ListOptions{}
+		for {
+			var list *baremetal.ListDBVersions
+			if list, e = s.Client.ListDBVersions(compartmentID, versionOpts); e != nil {
+				return
+			}
+			for _, v := range list.DBVersions {
+				versions = append(versions, v.Version)
+			}
+			if hasNextPage := options.SetNextPageOption(list.NextPage, &versionOpts.PageListOptions); !hasNextPage {
+				break
+			}
+		}
+		found := false
+		for _, v := range versions {
+			found = found || v == dbVersion
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("db_version %q is not supported, supported versions are %s", dbVersion, strings.Join(versions, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("DB system can't be launched:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return
+}
+
 func (s *DBSystemResourceCrud) Create() (e error) {
 	availabilityDomain := s.D.Get("availability_domain").(string)
 	compartmentID := s.D.Get("compartment_id").(string)
@@ -264,6 +380,7 @@ func (s *DBSystemResourceCrud) Create() (e error) {
 		opts.Hostname = hostname.(string)
 	}
 
+	opts.RetryToken = s.RetryToken
 	s.Res, e = s.Client.LaunchDBSystem(
 		availabilityDomain, compartmentID, shape, subnetID,
 		sshPublicKeys, cpuCoreCount, opts,
@@ -277,6 +394,28 @@ func (s *DBSystemResourceCrud) Get() (e error) {
 	return
 }
 
+// Update scales the CPU cores, replaces the SSH keys or renames the DB system
+// in place.
+func (s *DBSystemResourceCrud) Update() (e error) {
+	opts := &baremetal.UpdateDBSystemOptions{}
+	if s.D.HasChange("cpu_core_count") {
+		opts.CPUCoreCount = uint64(s.D.Get("cpu_core_count").(int))
+	}
+	if s.D.HasChange("display_name") {
+		opts.DisplayName = s.D.Get("display_name").(string)
+	}
+	if s.D.HasChange("ssh_public_keys") {
+		opts.SSHPublicKeys = []string{}
+		for _, key := range s.D.Get("ssh_public_keys").([]interface{}) {
+			opts.SSHPublicKeys = append(opts.SSHPublicKeys, key.(string))
+		}
+	}
+	opts.IfMatch = s.IfMatch()
+
+	s.Res, e = s.Client.UpdateDBSystem(s.D.Id(), opts)
+	return
+}
+
 func (s *DBSystemResourceCrud) SetData() {
 	s.D.Set("availability_domain", s.Res.AvailabilityDomain)
 	s.D.Set("compartment_id", s.Res.CompartmentID)
@@ -298,5 +437,5 @@ func (s *DBSystemResourceCrud) SetData() {
 }
 
 func (s *DBSystemResourceCrud) Delete() (e error) {
-	return s.Client.TerminateDBSystem(s.D.Id(), nil)
+	return s.Client.TerminateDBSystem(s.D.Id(), s.IfMatchOptions())
 }
@@ -3,6 +3,9 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/MustWin/baremetal-sdk-go"
@@ -10,8 +13,11 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	//"strconv"
+
+	"github.com/oracle/terraform-provider-baremetal/client/mocks"
 )
 
 type ResourceDatabaseDBSystemTestSuite struct {
@@ -64,6 +70,102 @@ func (s *ResourceDatabaseDBSystemTestSuite) TestCreateResourceDatabaseDBSystem()
 	})
 }
 
+func (s *ResourceDatabaseDBSystemTestSuite) TestUpdateResourceDatabaseDBSystemInPlace() {
+	config := strings.NewReplacer(
+		`cpu_core_count = "${var.CPUCoreCount}"`, `cpu_core_count = "4"`,
+		`ssh_public_keys = ["${var.ssh_public_key}"]`, `ssh_public_keys = ["${var.ssh_public_key}", "ssh-rsa AAAAB3NzaC1yc2E rotated"]`,
+		`display_name = "MyTFDatabaseNode0"`, `display_name = "MyTFDatabaseNode1"`,
+	).Replace(s.Config)
+
+	var id string
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+				Check: resource.ComposeTestCheckFunc(
+					func(ts *terraform.State) error {
+						id = ts.RootModule().Resources[s.ResourceName].Primary.ID
+						return nil
+					},
+					resource.TestCheckResourceAttr(s.ResourceName, "cpu_core_count", "2"),
+				),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					func(ts *terraform.State) error {
+						if newID := ts.RootModule().Resources[s.ResourceName].Primary.ID; newID != id {
+							return fmt.Errorf("DB system was recreated, %s became %s", id, newID)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(s.ResourceName, "cpu_core_count", "4"),
+					resource.TestCheckResourceAttr(s.ResourceName, "ssh_public_keys.#", "2"),
+					resource.TestCheckResourceAttr(s.ResourceName, "ssh_public_keys.1", "ssh-rsa AAAAB3NzaC1yc2E rotated"),
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "MyTFDatabaseNode1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "state", baremetal.ResourceAvailable),
+				),
+			},
+		},
+	})
+}
+
+func (s *ResourceDatabaseDBSystemTestSuite) TestLaunchResourceDatabaseDBSystemIsValidated() {
+	tooManyCores := strings.Replace(s.Config, `cpu_core_count = "${var.CPUCoreCount}"`, `cpu_core_count = "72"`, 1)
+	badVersion := strings.Replace(s.Config, `db_version = "${var.DBVersion}"`, `db_version = "9.2.0.1"`, 1)
+	racStandard := strings.NewReplacer(
+		`shape = "${var.DBNodeShape}"`, `shape = "BM.RACLocalStorage1.72"`,
+		`database_edition = "${var.DBEdition}"`, `database_edition = "STANDARD_EDITION"`,
+	).Replace(s.Config)
+	missingShape := strings.Replace(s.Config, `shape = "${var.DBNodeShape}"`, `shape = "BM.Nonexistent1.1"`, 1)
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config:      tooManyCores,
+				ExpectError: regexp.MustCompile("cpu_core_count 72 is out of range for shape BM.DenseIO1.36, which has 36 cores available"),
+			},
+			{
+				Config:      badVersion,
+				ExpectError: regexp.MustCompile(`db_version "9.2.0.1" is not supported, supported versions are 11.2.0.4, 12.1.0.2, 12.2.0.1`),
+			},
+			{
+				Config:      racStandard,
+				ExpectError: regexp.MustCompile("database_edition STANDARD_EDITION is not supported by shape BM.RACLocalStorage1.72, it needs ENTERPRISE_EDITION_EXTREME_PERFORMANCE"),
+			},
+			{
+				Config:      missingShape,
+				ExpectError: regexp.MustCompile(`shape "BM.Nonexistent1.1" is not available in`),
+			},
+		},
+	})
+}
+
+// Supported operations aren't documented, so a list without LaunchDbSystem
+// doesn't stop a launch.
+func TestLaunchDBSystemWithoutListedOperation(t *testing.T) {
+	client := &mocks.BareMetalClient{}
+	client.On("ListSupportedOperations").Return(&baremetal.ListSupportedOperations{}, nil)
+	client.On("ListDBSystemShapes", "ad", "compartment", mock.Anything).Return(&baremetal.ListDBSystemShapes{
+		DBSystemShapes: []baremetal.DBSystemShape{{Name: "BM.DenseIO1.36", AvailableCoreCount: 36}},
+	}, nil)
+
+	d := DBSystemResource().Data(nil)
+	d.Set("availability_domain", "ad")
+	d.Set("compartment_id", "compartment")
+	d.Set("shape", "BM.DenseIO1.36")
+	d.Set("cpu_core_count", 2)
+	sync := &DBSystemResourceCrud{}
+	sync.D = d
+	sync.Client = client
+
+	if e := sync.validateLaunch(); e != nil {
+		t.Error(e)
+	}
+}
+
 func TestResourceDatabaseDBSystemTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceDatabaseDBSystemTestSuite))
 }
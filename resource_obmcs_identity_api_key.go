@@ -6,7 +6,6 @@ import (
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
 
-	"errors"
 	"regexp"
 
 	"github.com/oracle/terraform-provider-baremetal/client"
@@ -123,7 +122,7 @@ func (s *APIKeyResourceCrud) Create() (e error) {
 	userID := s.D.Get("user_id").(string)
 	key := s.D.Get("key_value").(string)
 
-	s.Res, e = s.Client.UploadAPIKey(userID, key, nil)
+	s.Res, e = s.Client.UploadAPIKey(userID, key, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 
 	return
 }
@@ -131,6 +130,10 @@ func (s *APIKeyResourceCrud) Create() (e error) {
 func (s *APIKeyResourceCrud) Get() (e error) {
 	userID := s.D.Get("user_id").(string)
 	fingerprint := s.D.Get("fingerprint").(string)
+	if fingerprint == "" && s.Res != nil {
+		// Still waiting for the key we just uploaded to become active.
+		fingerprint = s.Res.Fingerprint
+	}
 
 	var res *baremetal.ListAPIKeyResponses
 	if res, e = s.Client.ListAPIKeys(userID); e != nil {
@@ -146,7 +149,7 @@ func (s *APIKeyResourceCrud) Get() (e error) {
 		}
 	}
 
-	return errors.New("Specified APIKEY does not exist")
+	return crud.NewNotFoundError("Specified APIKEY does not exist")
 }
 
 func (s *APIKeyResourceCrud) SetData() {
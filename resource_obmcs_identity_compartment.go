@@ -85,7 +85,7 @@ func (s *CompartmentResourceCrud) CreatedTarget() []string {
 func (s *CompartmentResourceCrud) Create() (e error) {
 	name := s.D.Get("name").(string)
 	description := s.D.Get("description").(string)
-	s.Res, e = s.Client.CreateCompartment(name, description, nil)
+	s.Res, e = s.Client.CreateCompartment(name, description, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	// Compartments can't be destroyed, so we shouldn't complain about them being created.
 	if e != nil && strings.Contains(e.Error(), "already exists") {
 		e = nil
@@ -114,6 +114,7 @@ func (s *CompartmentResourceCrud) Update() (e error) {
 	if description, ok := s.D.GetOk("description"); ok {
 		opts.Description = description.(string)
 	}
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateCompartment(s.D.Id(), opts)
 	return
 }
@@ -33,7 +33,7 @@ func (s *ResourceIdentityCompartmentTestSuite) SetupTest() {
 	}
 
 	s.Provider = Provider(configfn)
-	p := s.Provider.(*schema.Provider)
+	p := s.Provider.(*provider).Provider
 	res := p.ResourcesMap["baremetal_identity_compartment"]
 	res.Delete = func(d *schema.ResourceData, m interface{}) (e error) {
 		return nil
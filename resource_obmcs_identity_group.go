@@ -96,7 +96,7 @@ func (s *GroupSync) ExtraWaitPostCreateDelete() time.Duration {
 func (s *GroupSync) Create() (e error) {
 	name := s.D.Get("name").(string)
 	description := s.D.Get("description").(string)
-	s.Res, e = s.Client.CreateGroup(name, description, nil)
+	s.Res, e = s.Client.CreateGroup(name, description, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	return
 }
 
@@ -111,6 +111,7 @@ func (s *GroupSync) Update() (e error) {
 		opts.Description = description.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateGroup(s.D.Id(), opts)
 	return
 }
@@ -124,5 +125,5 @@ func (s *GroupSync) SetData() {
 }
 
 func (s *GroupSync) Delete() (e error) {
-	return s.Client.DeleteGroup(s.D.Id(), nil)
+	return s.Client.DeleteGroup(s.D.Id(), s.IfMatchOptions())
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
 type ResourceIdentityGroupTestSuite struct {
@@ -128,6 +130,41 @@ func (s *ResourceIdentityGroupTestSuite) TestUpdateResourceIdentityGroupDescript
 	})
 }
 
+func (s *ResourceIdentityGroupTestSuite) TestUpdateResourceIdentityGroupWithETags() {
+	api := &crud.Client{BareMetalClient: s.Client, Settings: crud.Settings{UseETags: true}}
+	providers := map[string]terraform.ResourceProvider{
+		"baremetal": Provider(func(d *schema.ResourceData) (interface{}, error) {
+			return api, nil
+		}),
+	}
+
+	c := `
+		resource "baremetal_identity_group" "t" {
+			name = "groupname"
+			description = "newdesc!"
+		}
+	`
+
+	c += testProviderConfig()
+
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: s.Config,
+				Check:  resource.TestCheckResourceAttrSet(s.ResourceName, "etag"),
+			},
+			{
+				Config: c,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "description", "newdesc!"),
+					resource.TestCheckResourceAttrSet(s.ResourceName, "etag"),
+				),
+			},
+		},
+	})
+}
+
 func (s *ResourceIdentityGroupTestSuite) TestFailedUpdateResourceIdentityGroupDescription() {
 
 	c := `
@@ -121,7 +121,9 @@ func (s *PolicyResourceCrud) Create() (e error) {
 	compartmentID := s.D.Get("compartment_id").(string)
 	statements := s.toStringArray(s.D.Get("statements"))
 
-	s.Res, e = s.Client.CreatePolicy(name, description, compartmentID, statements, nil)
+	opts := &baremetal.CreatePolicyOptions{}
+	opts.RetryToken = s.RetryToken
+	s.Res, e = s.Client.CreatePolicy(name, description, compartmentID, statements, opts)
 	return
 }
 
@@ -141,6 +143,7 @@ func (s *PolicyResourceCrud) Update() (e error) {
 		opts.Statements = statements
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdatePolicy(s.D.Id(), opts)
 	return
 }
@@ -155,5 +158,5 @@ func (s *PolicyResourceCrud) SetData() {
 }
 
 func (s *PolicyResourceCrud) Delete() (e error) {
-	return s.Client.DeletePolicy(s.D.Id(), nil)
+	return s.Client.DeletePolicy(s.D.Id(), s.IfMatchOptions())
 }
@@ -21,6 +21,7 @@ func SwiftPasswordResource() *schema.Resource {
 		Update:   updateSwiftPassword,
 		Delete:   deleteSwiftPassword,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -119,7 +120,7 @@ func (s *SwiftPasswordResourceCrud) Get() (e error) {
 func (s *SwiftPasswordResourceCrud) Create() (e error) {
 	userID := s.D.Get("user_id").(string)
 	desc := s.D.Get("description").(string)
-	s.Res, e = s.Client.CreateSwiftPassword(userID, desc, nil)
+	s.Res, e = s.Client.CreateSwiftPassword(userID, desc, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	return
 }
 
@@ -130,13 +131,14 @@ func (s *SwiftPasswordResourceCrud) Update() (e error) {
 		opts.Description = description.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateSwiftPassword(s.D.Id(), userID, opts)
 	return
 }
 
 func (s *SwiftPasswordResourceCrud) Delete() (e error) {
 	userID := s.D.Get("user_id").(string)
-	return s.Client.DeleteSwiftPassword(s.D.Id(), userID, nil)
+	return s.Client.DeleteSwiftPassword(s.D.Id(), userID, s.IfMatchOptions())
 }
 
 func (s *SwiftPasswordResourceCrud) SetData() {
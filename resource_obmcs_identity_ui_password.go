@@ -83,7 +83,7 @@ func (s *UIPasswordResourceCrud) ID() string {
 
 func (s *UIPasswordResourceCrud) Create() (e error) {
 	userID := s.D.Get("user_id").(string)
-	s.Res, e = s.Client.CreateOrResetUIPassword(userID, nil)
+	s.Res, e = s.Client.CreateOrResetUIPassword(userID, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	return
 }
 
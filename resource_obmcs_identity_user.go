@@ -74,7 +74,7 @@ func (s *UserResourceCrud) State() string {
 func (s *UserResourceCrud) Create() (e error) {
 	name := s.D.Get("name").(string)
 	description := s.D.Get("description").(string)
-	s.Res, e = s.Client.CreateUser(name, description, nil)
+	s.Res, e = s.Client.CreateUser(name, description, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	return
 }
 
@@ -105,6 +105,7 @@ func (s *UserResourceCrud) Update() (e error) {
 		opts.Description = description.(string)
 	}
 
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateUser(s.D.Id(), opts)
 	return
 }
@@ -118,5 +119,5 @@ func (s *UserResourceCrud) SetData() {
 }
 
 func (s *UserResourceCrud) Delete() (e error) {
-	return s.Client.DeleteUser(s.D.Id(), nil)
+	return s.Client.DeleteUser(s.D.Id(), s.IfMatchOptions())
 }
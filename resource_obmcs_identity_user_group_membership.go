@@ -20,6 +20,7 @@ func UserGroupMembershipResource() *schema.Resource {
 		Read:     readUserGroupMembership,
 		Delete:   deleteUserGroupMembership,
 		Schema: map[string]*schema.Schema{
+			"etag": crud.ETagSchema,
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -97,7 +98,7 @@ func (s *UserGroupMembershipResourceCrud) State() string {
 func (s *UserGroupMembershipResourceCrud) Create() (e error) {
 	userID := s.D.Get("user_id").(string)
 	groupID := s.D.Get("group_id").(string)
-	s.Res, e = s.Client.AddUserToGroup(userID, groupID, nil)
+	s.Res, e = s.Client.AddUserToGroup(userID, groupID, &baremetal.RetryTokenOptions{RetryToken: s.RetryToken})
 	return
 }
 
@@ -132,5 +133,5 @@ func (s *UserGroupMembershipResourceCrud) SetData() {
 }
 
 func (s *UserGroupMembershipResourceCrud) Delete() (e error) {
-	return s.Client.DeleteUserGroupMembership(s.D.Id(), nil)
+	return s.Client.DeleteUserGroupMembership(s.D.Id(), s.IfMatchOptions())
 }
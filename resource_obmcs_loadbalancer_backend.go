@@ -15,6 +15,9 @@ import (
 
 func LoadBalancerBackendResource() *schema.Resource {
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancerBackend,
+		},
 		Create: createLoadBalancerBackend,
 		Read:   readLoadBalancerBackend,
 		Update: updateLoadBalancerBackend,
@@ -166,6 +169,7 @@ func (s *LoadBalancerBackendResourceCrud) Create() (e error) {
 	}
 
 	var workReqID string
+	opts.RetryToken = s.RetryToken
 	workReqID, e = s.Client.CreateBackend(
 		s.D.Get("load_balancer_id").(string),
 		s.D.Get("backendset_name").(string),
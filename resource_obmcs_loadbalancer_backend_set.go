@@ -12,6 +12,9 @@ import (
 
 func LoadBalancerBackendSetResource() *schema.Resource {
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancerChild("name"),
+		},
 		Create: createLoadBalancerBackendSet,
 		Read:   readLoadBalancerBackendSet,
 		Update: updateLoadBalancerBackendSet,
@@ -125,6 +128,8 @@ func (s *LoadBalancerBackendSetResourceCrud) DeletedTarget() []string {
 }
 
 func (s *LoadBalancerBackendSetResourceCrud) Create() (e error) {
+	opts := &baremetal.LoadBalancerOptions{}
+	opts.RetryToken = s.RetryToken
 	workReqID, e := s.Client.CreateBackendSet(
 		s.D.Get("load_balancer_id").(string),
 		s.D.Get("name").(string),
@@ -132,7 +137,7 @@ func (s *LoadBalancerBackendSetResourceCrud) Create() (e error) {
 		s.backends(),
 		s.healthChecker(),
 		s.sslConfig(),
-		nil,
+		opts,
 	)
 	if e != nil {
 		return
@@ -237,34 +242,12 @@ func (s *LoadBalancerBackendSetResourceCrud) Delete() (e error) {
 	return
 }
 
-func (s *LoadBalancerBackendSetResourceCrud) sslConfig() (sslConfig *baremetal.SSLConfiguration) {
-	vs := s.D.Get("ssl_configuration").([]interface{})
-	if len(vs) == 1 {
-		sslConfig = new(baremetal.SSLConfiguration)
-		v := vs[0].(map[string]interface{})
-		sslConfig.CertificateName = v["certificate_name"].(string)
-		sslConfig.VerifyDepth = v["verify_depth"].(int)
-		sslConfig.VerifyPeerCertificate = v["verify_peer_certificate"].(bool)
-		return sslConfig
-	}
-
-	return nil
+func (s *LoadBalancerBackendSetResourceCrud) sslConfig() *baremetal.SSLConfiguration {
+	return loadBalancerSSLConfig(s.D.Get("ssl_configuration").([]interface{}))
 }
 
 func (s *LoadBalancerBackendSetResourceCrud) healthChecker() *baremetal.HealthChecker {
-
-	vs := s.D.Get("health_checker").([]interface{})
-	if len(vs) == 1 {
-		healthChecker := new(baremetal.HealthChecker)
-		v := vs[0].(map[string]interface{})
-		healthChecker.IntervalInMS = v["interval_ms"].(int)
-		healthChecker.Port = v["port"].(int)
-		healthChecker.Protocol = v["protocol"].(string)
-		healthChecker.ResponseBodyRegex = v["response_body_regex"].(string)
-		healthChecker.URLPath = v["url_path"].(string)
-		return healthChecker
-	}
-	return nil
+	return loadBalancerHealthChecker(s.D.Get("health_checker").([]interface{}))
 }
 func (s *LoadBalancerBackendSetResourceCrud) backends() []baremetal.Backend {
 	vs := s.D.Get("backend").([]interface{})
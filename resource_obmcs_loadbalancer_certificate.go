@@ -3,7 +3,6 @@
 package main
 
 import (
-	"errors"
 
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -13,6 +12,9 @@ import (
 
 func LoadBalancerCertificateResource() *schema.Resource {
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancerChild("certificate_name"),
+		},
 		Create: createLoadBalancerCertificate,
 		Read:   readLoadBalancerCertificate,
 		Delete: deleteLoadBalancerCertificate,
@@ -33,15 +35,17 @@ func LoadBalancerCertificateResource() *schema.Resource {
 				ForceNew: true,
 			},
 			"passphrase": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "",
+				DiffSuppressFunc: suppressImportedSecretDiff,
 			},
 			"private_key": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressImportedSecretDiff,
 			},
 			"public_certificate": {
 				Type:     schema.TypeString,
@@ -130,6 +134,7 @@ func (s *LoadBalancerCertificateResourceCrud) Create() (e error) {
 	opts := &baremetal.LoadBalancerOptions{}
 
 	var workReqID string
+	opts.RetryToken = s.RetryToken
 	workReqID, e = s.Client.CreateCertificate(
 		s.D.Get("load_balancer_id").(string),
 		s.D.Get("certificate_name").(string),
@@ -167,12 +172,29 @@ func (s *LoadBalancerCertificateResourceCrud) Get() (e error) {
 			return
 		}
 	}
-	e = errors.New("Certificate does not exist")
+	e = crud.NewNotFoundError("Certificate does not exist")
 	return
 }
 
 func (s *LoadBalancerCertificateResourceCrud) SetData() {
-	// Noop for this resource
+	if s.Resource == nil {
+		return
+	}
+	// The certificates are only read back when importing, so that formatting
+	// changes made by the service don't force a new certificate. The private
+	// key and passphrase are never returned.
+	if _, ok := s.D.GetOk("public_certificate"); !ok {
+		s.D.Set("public_certificate", s.Resource.PublicCertificate)
+	}
+	if _, ok := s.D.GetOk("ca_certificate"); !ok && s.Resource.CACertificate != "" {
+		s.D.Set("ca_certificate", s.Resource.CACertificate)
+	}
+}
+
+// suppressImportedSecretDiff ignores the secrets of imported certificates,
+// which the service doesn't return.
+func suppressImportedSecretDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old == "" && d.Id() != ""
 }
 
 func (s *LoadBalancerCertificateResourceCrud) Delete() (e error) {
@@ -3,7 +3,6 @@
 package main
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/MustWin/baremetal-sdk-go"
@@ -15,6 +14,9 @@ import (
 
 func LoadBalancerListenerResource() *schema.Resource {
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancerChild("name"),
+		},
 		Create: createLoadBalancerListener,
 		Read:   readLoadBalancerListener,
 		Update: updateLoadBalancerListener,
@@ -129,21 +131,13 @@ func (s *LoadBalancerListenerResourceCrud) DeletedTarget() []string {
 	}
 }
 
-func (s *LoadBalancerListenerResourceCrud) sslConfig() (sslConfig *baremetal.SSLConfiguration) {
-	vs := s.D.Get("ssl_configuration").([]interface{})
-	if len(vs) == 1 {
-		sslConfig = new(baremetal.SSLConfiguration)
-		v := vs[0].(map[string]interface{})
-		sslConfig.CertificateName = v["certificate_name"].(string)
-		sslConfig.VerifyDepth = v["verify_depth"].(int)
-		sslConfig.VerifyPeerCertificate = v["verify_peer_certificate"].(bool)
-		return sslConfig
-	}
-
-	return nil
+func (s *LoadBalancerListenerResourceCrud) sslConfig() *baremetal.SSLConfiguration {
+	return loadBalancerSSLConfig(s.D.Get("ssl_configuration").([]interface{}))
 }
 
 func (s *LoadBalancerListenerResourceCrud) Create() (e error) {
+	opts := &baremetal.LoadBalancerOptions{}
+	opts.RetryToken = s.RetryToken
 	var workReqID string
 	workReqID, e = s.Client.CreateListener(
 		s.D.Get("load_balancer_id").(string),
@@ -152,7 +146,7 @@ func (s *LoadBalancerListenerResourceCrud) Create() (e error) {
 		s.D.Get("protocol").(string),
 		s.D.Get("port").(int),
 		s.sslConfig(),
-		nil, // neither OPCClientRequestID nor RetryToken is needed
+		opts,
 	)
 	if e != nil {
 		return
@@ -185,7 +179,7 @@ func (s *LoadBalancerListenerResourceCrud) GetListener(loadBalancerID, name stri
 	if l.Name == name {
 		return &l, nil
 	}
-	return nil, fmt.Errorf("Listener %s on load balancer %s does not exist", name, loadBalancerID)
+	return nil, crud.NewNotFoundError("Listener %s on load balancer %s does not exist", name, loadBalancerID)
 }
 
 func (s *LoadBalancerListenerResourceCrud) Update() (e error) {
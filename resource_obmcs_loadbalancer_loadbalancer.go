@@ -3,17 +3,22 @@
 package main
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
 	"github.com/MustWin/baremetal-sdk-go"
 	"github.com/hashicorp/terraform/helper/schema"
 
-	"strings"
-
 	"github.com/oracle/terraform-provider-baremetal/client"
 	"github.com/oracle/terraform-provider-baremetal/crud"
 )
 
 func LoadBalancerResource() *schema.Resource {
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			State: importLoadBalancer,
+		},
 		Create: createLoadBalancer,
 		Read:   readLoadBalancer,
 		Update: updateLoadBalancer,
@@ -41,6 +46,11 @@ func LoadBalancerResource() *schema.Resource {
 				Required: true,
 			},
 			// }
+			// Optional {
+			"backend_set": loadBalancerBackendSetsSchema,
+			"certificate": loadBalancerCertificatesSchema,
+			"listener":    loadBalancerListenersSchema,
+			// }
 			// Computed {
 			"id": {
 				Type:     schema.TypeString,
@@ -154,11 +164,12 @@ func (s *LoadBalancerResourceCrud) Create() (e error) {
 	opts := &baremetal.CreateOptions{}
 	opts.DisplayName = s.D.Get("display_name").(string)
 
+	opts.RetryToken = s.RetryToken
 	workReqID, e := s.Client.CreateLoadBalancer(
-		nil,
-		nil,
+		loadBalancerBackendSets(s.D.Get("backend_set").(*schema.Set)),
+		loadBalancerCertificates(s.D.Get("certificate").(*schema.Set)),
 		s.D.Get("compartment_id").(string),
-		nil,
+		loadBalancerListeners(s.D.Get("listener").(*schema.Set)),
 		s.D.Get("shape").(string),
 		sns,
 		opts)
@@ -191,22 +202,158 @@ func (s *LoadBalancerResourceCrud) Get() (e error) {
 	return
 }
 
-// Update makes a request to update the load balancer
+// Update makes a request to update the load balancer, then reconciles its
+// backend sets, listeners and certificates with their blocks
 func (s *LoadBalancerResourceCrud) Update() (e error) {
-	opts := &baremetal.UpdateOptions{}
-	if displayName, ok := s.D.GetOk("display_name"); ok {
-		opts.DisplayName = displayName.(string)
+	if s.D.HasChange("display_name") {
+		opts := &baremetal.UpdateOptions{}
+		if displayName, ok := s.D.GetOk("display_name"); ok {
+			opts.DisplayName = displayName.(string)
+		}
+
+		var workReqID string
+		workReqID, e = s.Client.UpdateLoadBalancer(s.D.Id(), opts)
+		if e != nil {
+			return
+		}
+		if e = s.waitForWorkRequest(workReqID); e != nil {
+			return
+		}
+		s.D.SetPartial("display_name")
 	}
 
-	var workReqID string
-	workReqID, e = s.Client.UpdateLoadBalancer(s.D.Id(), opts)
-	if e != nil {
+	if e = s.updateChildren(); e != nil {
 		return
 	}
-	s.WorkRequest, e = s.Client.GetWorkRequest(workReqID, nil)
+	return s.Get()
+}
+
+func (s *LoadBalancerResourceCrud) waitForWorkRequest(workReqID string) (e error) {
+	if s.WorkRequest, e = s.Client.GetWorkRequest(workReqID, nil); e != nil {
+		return
+	}
+	return crud.LoadBalancerWaitForWorkRequest(s.Client, s.D, s.WorkRequest)
+}
+
+// updateChildren creates, updates and deletes backend sets, listeners and
+// certificates one work request at a time. Certificates and backend sets are
+// created before the listeners that use them, and deleted after.
+func (s *LoadBalancerResourceCrud) updateChildren() (e error) {
+	id := s.D.Id()
+
+	oldRaw, newRaw := s.D.GetChange("certificate")
+	oldCerts := loadBalancerCertificates(oldRaw.(*schema.Set))
+	newCerts := loadBalancerCertificates(newRaw.(*schema.Set))
+	oldRaw, newRaw = s.D.GetChange("backend_set")
+	oldSets := loadBalancerBackendSets(oldRaw.(*schema.Set))
+	newSets := loadBalancerBackendSets(newRaw.(*schema.Set))
+	oldRaw, newRaw = s.D.GetChange("listener")
+	oldListeners := loadBalancerListeners(oldRaw.(*schema.Set))
+	newListeners := loadBalancerListeners(newRaw.(*schema.Set))
+
+	for name, c := range newCerts {
+		old, ok := oldCerts[name]
+		if ok && reflect.DeepEqual(old, c) {
+			continue
+		}
+		if ok {
+			return fmt.Errorf("Certificates cannot be updated, use a new certificate_name instead of changing certificate %s", name)
+		}
+		var workReqID string
+		workReqID, e = s.Client.CreateCertificate(id, name, c.CACertificate, c.PrivateKey, c.Passphrase, c.PublicCertificate, nil)
+		if e == nil {
+			e = s.waitForWorkRequest(workReqID)
+		}
+		if e != nil {
+			return
+		}
+	}
+
+	for name, b := range newSets {
+		old, ok := oldSets[name]
+		if ok && reflect.DeepEqual(old, b) {
+			continue
+		}
+		var workReqID string
+		if ok {
+			opts := &baremetal.UpdateLoadBalancerBackendSetOptions{
+				Backends:      b.Backends,
+				HealthChecker: b.HealthChecker,
+				Policy:        b.Policy,
+				SSLConfig:     b.SSLConfig,
+			}
+			workReqID, e = s.Client.UpdateBackendSet(id, name, opts)
+		} else {
+			workReqID, e = s.Client.CreateBackendSet(id, name, b.Policy, b.Backends, b.HealthChecker, b.SSLConfig, nil)
+		}
+		if e == nil {
+			e = s.waitForWorkRequest(workReqID)
+		}
+		if e != nil {
+			return
+		}
+	}
+
+	for name, l := range newListeners {
+		old, ok := oldListeners[name]
+		if ok && reflect.DeepEqual(old, l) {
+			continue
+		}
+		var workReqID string
+		if ok {
+			opts := &baremetal.UpdateLoadBalancerListenerOptions{
+				DefaultBackendSetName: l.DefaultBackendSetName,
+				Port:                  l.Port,
+				Protocol:              l.Protocol,
+				SSLConfig:             l.SSLConfig,
+			}
+			workReqID, e = s.Client.UpdateListener(id, name, opts)
+		} else {
+			workReqID, e = s.Client.CreateListener(id, name, l.DefaultBackendSetName, l.Protocol, l.Port, l.SSLConfig, nil)
+		}
+		if e == nil {
+			e = s.waitForWorkRequest(workReqID)
+		}
+		if e != nil {
+			return
+		}
+	}
+
+	for name := range oldListeners {
+		if _, ok := newListeners[name]; ok {
+			continue
+		}
+		if e = s.deleteChild(s.Client.DeleteListener, name); e != nil {
+			return
+		}
+	}
+	for name := range oldSets {
+		if _, ok := newSets[name]; ok {
+			continue
+		}
+		if e = s.deleteChild(s.Client.DeleteBackendSet, name); e != nil {
+			return
+		}
+	}
+	for name := range oldCerts {
+		if _, ok := newCerts[name]; ok {
+			continue
+		}
+		if e = s.deleteChild(s.Client.DeleteCertificate, name); e != nil {
+			return
+		}
+	}
 	return
 }
 
+func (s *LoadBalancerResourceCrud) deleteChild(del func(loadBalancerID, name string, opts *baremetal.ClientRequestOptions) (string, error), name string) error {
+	workReqID, e := del(s.D.Id(), name, nil)
+	if e != nil {
+		return e
+	}
+	return s.waitForWorkRequest(workReqID)
+}
+
 // SetData populates the resourceData from the model
 func (s *LoadBalancerResourceCrud) SetData() {
 	// The first time this is called, we haven't actually fetched the resource yet, we just got a work request
@@ -225,6 +372,14 @@ func (s *LoadBalancerResourceCrud) SetData() {
 			ip_addresses[i] = ad.IPAddress
 		}
 		s.D.Set("ip_addresses", ip_addresses)
+		// Backend sets, listeners and certificates that aren't declared
+		// inline belong to their own resources.
+		backendSets := loadBalancerBackendSets(s.D.Get("backend_set").(*schema.Set))
+		s.D.Set("backend_set", backendSetsToSet(s.Resource.BackendSets, backendSets))
+		listeners := loadBalancerListeners(s.D.Get("listener").(*schema.Set))
+		s.D.Set("listener", listenersToSet(s.Resource.Listeners, listeners))
+		certificates := loadBalancerCertificates(s.D.Get("certificate").(*schema.Set))
+		s.D.Set("certificate", certificatesToSet(s.Resource.Certificates, certificates))
 	}
 }
 
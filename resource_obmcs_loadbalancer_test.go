@@ -325,6 +325,149 @@ resource "baremetal_load_balancer_backend" "f" {
 	})
 }
 
+func (s *ResourceLoadBalancerTestSuite) TestImportResourceLoadBalancer() {
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: loadbalancerConfig + testProviderConfig(),
+			},
+			{
+				ResourceName:      s.ResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// inlineLoadBalancerConfig defines the backend sets, listeners and
+// certificates of the load balancer in its own blocks.
+func inlineLoadBalancerConfig(displayName, blocks string) string {
+	return subnetConfig + `
+resource "baremetal_core_subnet" "WebSubnetAD2" {
+  availability_domain = "${data.baremetal_identity_availability_domains.ADs.availability_domains.1.name}"
+  cidr_block = "10.0.2.0/24"
+  display_name = "WebSubnetAD2"
+  compartment_id = "${var.compartment_id}"
+  vcn_id = "${baremetal_core_virtual_network.t.id}"
+  route_table_id = "${baremetal_core_route_table.RouteForComplete.id}"
+  security_list_ids = ["${baremetal_core_security_list.WebSubnet.id}"]
+}
+
+data "baremetal_load_balancer_shapes" "t" {
+  compartment_id = "${var.compartment_id}"
+}
+
+resource "baremetal_load_balancer" "t" {
+  shape          = "${data.baremetal_load_balancer_shapes.t.shapes.0.name}"
+  compartment_id = "${var.compartment_id}"
+  display_name   = "` + displayName + `"
+  subnet_ids     = ["${baremetal_core_subnet.WebSubnetAD1.id}", "${baremetal_core_subnet.WebSubnetAD2.id}"]
+
+  certificate {
+    certificate_name   = "inline_certificate"
+    public_certificate = "stub_public_certificate"
+    private_key        = "stub_private_key"
+  }
+
+  backend_set {
+    name   = "inline_backendset"
+    policy = "ROUND_ROBIN"
+
+    health_checker {
+      port                = 1234
+      protocol            = "HTTP"
+      response_body_regex = ".*"
+      url_path            = "/"
+    }
+
+    backend {
+      ip_address = "1.2.3.4"
+      port       = 1234
+    }
+  }
+` + blocks + `
+}
+` + testProviderConfig()
+}
+
+func (s *ResourceLoadBalancerTestSuite) TestInlineChildrenResourceLoadBalancer() {
+	httpListener := `
+  listener {
+    name                     = "inline_http"
+    default_backend_set_name = "inline_backendset"
+    port                     = 80
+    protocol                 = "HTTP"
+  }
+`
+	httpsListener := `
+  listener {
+    name                     = "inline_https"
+    default_backend_set_name = "inline_backendset"
+    port                     = 443
+    protocol                 = "HTTP"
+
+    ssl_configuration {
+      certificate_name = "inline_certificate"
+    }
+  }
+`
+	standaloneListener := `
+resource "baremetal_load_balancer_listener" "standalone" {
+  load_balancer_id         = "${baremetal_load_balancer.t.id}"
+  name                     = "standalone"
+  default_backend_set_name = "inline_backendset"
+  port                     = 8080
+  protocol                 = "HTTP"
+}
+`
+	resource.UnitTest(s.T(), resource.TestCase{
+		Providers: s.Providers,
+		Steps: []resource.TestStep{
+			{
+				Config: inlineLoadBalancerConfig("lb_display_name", httpListener),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "certificate.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "backend_set.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "listener.#", "1"),
+				),
+			},
+			{
+				// Add a listener and rename the load balancer
+				Config: inlineLoadBalancerConfig("lb_display_name_updated", httpListener+httpsListener),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "display_name", "lb_display_name_updated"),
+					resource.TestCheckResourceAttr(s.ResourceName, "certificate.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "listener.#", "2"),
+				),
+			},
+			{
+				// Remove the first listener
+				Config: inlineLoadBalancerConfig("lb_display_name_updated", httpsListener),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "listener.#", "1"),
+					resource.TestCheckResourceAttr(s.ResourceName, "backend_set.#", "1"),
+				),
+			},
+			{
+				// A listener from its own resource isn't tracked inline
+				Config: inlineLoadBalancerConfig("lb_display_name_updated", httpsListener) + standaloneListener,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("baremetal_load_balancer_listener.standalone", "name", "standalone"),
+				),
+			},
+			{
+				Config:   inlineLoadBalancerConfig("lb_display_name_updated", httpsListener) + standaloneListener,
+				PlanOnly: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(s.ResourceName, "listener.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceLoadBalancerTestSuite(t *testing.T) {
 	suite.Run(t, new(ResourceLoadBalancerTestSuite))
 }
@@ -113,6 +113,7 @@ func (s *BucketResourceCrud) Update() (e error) {
 
 	accessType, _ := s.D.GetOk("access_type") //guaranteed to be there with Default value
 	opts.AccessType = baremetal.BucketAccessType(accessType.(string))
+	opts.IfMatch = s.IfMatch()
 	s.Res, e = s.Client.UpdateBucket(compartmentID, name, baremetal.Namespace(namespace), opts)
 	return
 }
@@ -120,5 +121,5 @@ func (s *BucketResourceCrud) Update() (e error) {
 func (s *BucketResourceCrud) Delete() (e error) {
 	name := s.D.Get("name").(string)
 	namespace := s.D.Get("namespace").(string)
-	return s.Client.DeleteBucket(name, baremetal.Namespace(namespace), nil)
+	return s.Client.DeleteBucket(name, baremetal.Namespace(namespace), s.IfMatchOptions())
 }
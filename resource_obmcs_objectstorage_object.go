@@ -108,6 +108,7 @@ func (s *ObjectResourceCrud) Delete() (e error) {
 	bucket := s.D.Get("bucket").(string)
 	object := s.D.Get("object").(string)
 	opts := &baremetal.DeleteObjectOptions{}
+	opts.IfMatch = s.IfMatch()
 
 	_, e = s.Client.DeleteObject(baremetal.Namespace(namespace), bucket, object, opts)
 	return
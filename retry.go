@@ -0,0 +1,100 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+func retrySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: descriptions["retry"],
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      7,
+					ValidateFunc: validation.IntBetween(1, 100),
+				},
+				"base_delay": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "2s",
+					ValidateFunc: validateDuration,
+				},
+				"max_delay": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "1m",
+					ValidateFunc: validateDuration,
+				},
+				"jitter": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"retryable_status_codes": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntBetween(400, 599),
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a duration such as 30s or 2m: %s", k, err))
+	}
+	return
+}
+
+// retryPolicy builds the policy described by the provider's retry block,
+// starting from crud.DefaultRetryPolicy.
+func retryPolicy(d *schema.ResourceData) (policy *crud.RetryPolicy, err error) {
+	policy = crud.DefaultRetryPolicy()
+
+	blocks, _ := d.Get("retry").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return
+	}
+	block := blocks[0].(map[string]interface{})
+
+	if v, ok := block["max_attempts"].(int); ok && v > 0 {
+		policy.MaxAttempts = uint(v)
+	}
+	if v, ok := block["base_delay"].(string); ok && v != "" {
+		if policy.BaseDelay, err = time.ParseDuration(v); err != nil {
+			return
+		}
+	}
+	if v, ok := block["max_delay"].(string); ok && v != "" {
+		if policy.MaxDelay, err = time.ParseDuration(v); err != nil {
+			return
+		}
+	}
+	if v, ok := block["jitter"].(bool); ok {
+		policy.Jitter = v
+	}
+	if codes, ok := block["retryable_status_codes"].([]interface{}); ok && len(codes) > 0 {
+		policy.RetryableStatusCodes = []int{}
+		for _, code := range codes {
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+		}
+	}
+	return
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2017, Oracle and/or its affiliates. All rights reserved.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/oracle/terraform-provider-baremetal/crud"
+)
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func (s *RetryPolicyTestSuite) TestDefault() {
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	policy, err := retryPolicy(d)
+	s.Require().NoError(err)
+	s.Equal(crud.DefaultRetryPolicy(), policy)
+}
+
+func (s *RetryPolicyTestSuite) TestBlock() {
+	d := (&schema.Resource{Schema: schemaMap()}).Data(nil)
+	s.Require().NoError(d.Set("retry", []interface{}{
+		map[string]interface{}{
+			"max_attempts":           3,
+			"base_delay":             "500ms",
+			"max_delay":              "10s",
+			"jitter":                 false,
+			"retryable_status_codes": []interface{}{500, 503},
+		},
+	}))
+
+	policy, err := retryPolicy(d)
+	s.Require().NoError(err)
+	s.Equal(&crud.RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            500 * time.Millisecond,
+		MaxDelay:             10 * time.Second,
+		RetryableStatusCodes: []int{500, 503},
+	}, policy)
+}
+
+func (s *RetryPolicyTestSuite) TestInvalidDelay() {
+	_, errs := validateDuration("soon", "retry.0.base_delay")
+	s.Len(errs, 1)
+}
+
+func TestRetryPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryPolicyTestSuite))
+}